@@ -0,0 +1,169 @@
+// Package aoc is a client for the Advent of Code website.
+// It downloads puzzle inputs and private leaderboards into the local data directory, throttling its requests to the site.
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+/* -------------------------------- Constants ------------------------------- */
+
+// The Advent of Code website.
+const DEFAULT_BASE_URL = "https://adventofcode.com"
+
+// The year of Advent of Code this repository solves.
+const DEFAULT_YEAR = 2024
+
+// The minimum time between requests to the website, shared by every run of the tool.
+const DEFAULT_MIN_INTERVAL = 5 * time.Second
+
+// The environment variable, and files, which may hold the session token.
+const SESSION_ENV = "AOC_SESSION"
+const SESSION_FILE = ".aoc-session"
+
+// The file within the data directory recording when the last request was made.
+const LAST_REQUEST_FILE = ".aoc-last-request"
+
+// Identifies this tool to the website, as requested by the Advent of Code maintainers.
+const USER_AGENT = "github.com/shane-holland/advent-of-code-2024"
+
+// ErrInputExists is returned when fetching an input which has already been downloaded.
+var ErrInputExists = errors.New("input already exists")
+
+// ErrNoSession is returned when no session token can be found.
+var ErrNoSession = errors.New("no session token found")
+
+/* ---------------------- Client Definition and Methods --------------------- */
+
+// Client makes requests to the Advent of Code website, and caches the results under DataDir.
+type Client struct {
+	BaseURL     string
+	Year        int
+	Session     string
+	DataDir     string
+	MinInterval time.Duration
+	HTTPClient  *http.Client
+
+	mu sync.Mutex
+}
+
+// NewClient returns a Client for the real website, using the given session token and data directory.
+func NewClient(session, dataDir string) *Client {
+	return &Client{
+		BaseURL:     DEFAULT_BASE_URL,
+		Year:        DEFAULT_YEAR,
+		Session:     session,
+		DataDir:     dataDir,
+		MinInterval: DEFAULT_MIN_INTERVAL,
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// InputPath returns the path an input for the given day is stored at.
+func (c *Client) InputPath(day int) string {
+	return filepath.Join(c.DataDir, fmt.Sprintf("day-%02d.txt", day))
+}
+
+// FetchInput downloads the input for a day and stores it in the data directory, returning its path.
+// An input which has already been downloaded is never fetched again, and ErrInputExists is returned.
+func (c *Client) FetchInput(day int) (string, error) {
+	path := c.InputPath(day)
+	if _, err := os.Stat(path); err == nil {
+		return path, fmt.Errorf("%w: %s", ErrInputExists, path)
+	}
+
+	body, err := c.Get(fmt.Sprintf("/%d/day/%d/input", c.Year, day))
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(c.DataDir, 0o755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, body, 0o644)
+}
+
+// Get makes an authenticated GET request to a path on the website, and returns the response body.
+func (c *Client) Get(path string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, strings.TrimRight(c.BaseURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(request)
+}
+
+// Do sends an authenticated request to the website, waiting first if a request was made too recently.
+// A response with a status other than 200 OK is returned as an error.
+func (c *Client) Do(request *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	request.Header.Set("User-Agent", USER_AGENT)
+
+	c.throttle()
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", request.Method, request.URL.Path, response.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// throttle waits until at least MinInterval has passed since the last request, then records this request.
+// The time of the last request is kept in the data directory, so the interval is respected across runs.
+func (c *Client) throttle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stamp := filepath.Join(c.DataDir, LAST_REQUEST_FILE)
+	if info, err := os.Stat(stamp); err == nil {
+		if wait := c.MinInterval - time.Since(info.ModTime()); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+
+	if err := os.MkdirAll(c.DataDir, 0o755); err == nil {
+		now := time.Now()
+		if err := os.WriteFile(stamp, []byte(now.Format(time.RFC3339)+"\n"), 0o644); err == nil {
+			os.Chtimes(stamp, now, now)
+		}
+	}
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// LoadSession returns the session token from the AOC_SESSION environment variable, or from a
+// .aoc-session file in the current directory or the user's config directory.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SESSION_ENV)); session != "" {
+		return session, nil
+	}
+
+	paths := []string{SESSION_FILE}
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "aoc", "session"))
+	}
+	for _, path := range paths {
+		if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) != "" {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", fmt.Errorf("%w: set %s or create %s", ErrNoSession, SESSION_ENV, SESSION_FILE)
+}
@@ -0,0 +1,279 @@
+package aoc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a client for a local stand-in of the website, storing data in a temporary directory.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "test-session" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("test-session", t.TempDir())
+	client.BaseURL = server.URL
+	client.MinInterval = 50 * time.Millisecond
+	return client, &requests
+}
+
+func TestFetchInput(t *testing.T) {
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2024/day/5/input", r.URL.Path)
+		assert.Equal(t, USER_AGENT, r.Header.Get("User-Agent"))
+		w.Write([]byte("47|53\n"))
+	})
+
+	path, err := client.FetchInput(5)
+	assert.NoError(t, err)
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, "47|53\n", string(data))
+
+	// The cached input is never fetched again
+	_, err = client.FetchInput(5)
+	assert.True(t, errors.Is(err, ErrInputExists))
+	assert.Equal(t, 1, *requests)
+}
+
+func TestFetchInputThrottles(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input"))
+	})
+
+	start := time.Now()
+	_, err := client.FetchInput(1)
+	assert.NoError(t, err)
+	_, err = client.FetchInput(2)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), client.MinInterval)
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := client.FetchInput(25)
+	assert.ErrorContains(t, err, "404")
+	_, statErr := os.Stat(client.InputPath(25))
+	assert.True(t, os.IsNotExist(statErr))
+
+	client.Session = ""
+	_, err = client.FetchInput(25)
+	assert.True(t, errors.Is(err, ErrNoSession))
+}
+
+func TestLoadSession(t *testing.T) {
+	t.Setenv(SESSION_ENV, " abc123\n")
+	session, err := LoadSession()
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", session)
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/2024/day/7/answer", r.URL.Path)
+		assert.Equal(t, "2", r.FormValue("level"))
+		if r.FormValue("answer") == "42" {
+			w.Write([]byte("<main><article><p>That's the right answer! You are <em>one gold star</em> closer.</p></article></main>"))
+			return
+		}
+		w.Write([]byte("<main><article><p>That's not the right answer; your answer is too low.  Please wait one minute.</p></article></main>"))
+	})
+
+	submission, err := client.SubmitAnswer(7, 2, "41")
+	assert.NoError(t, err)
+	assert.Equal(t, TooLow, submission.Result)
+	assert.Equal(t, "41", submission.Answer)
+
+	client.MinInterval = 0
+	submission, err = client.SubmitAnswer(7, 2, "42")
+	assert.NoError(t, err)
+	assert.Equal(t, Correct, submission.Result)
+	assert.Equal(t, "That's the right answer! You are one gold star closer.", submission.Message)
+}
+
+func TestParseSubmissionResponse(t *testing.T) {
+	tests := []struct {
+		page   string
+		result Result
+	}{
+		{"<article><p>That's the right answer!</p></article>", Correct},
+		{"<article><p>That's not the right answer.</p></article>", Wrong},
+		{"<article><p>That's not the right answer; your answer is too high.</p></article>", TooHigh},
+		{"<article><p>That's not the right answer; your answer is too low.</p></article>", TooLow},
+		{"<article><p>You gave an answer too recently. You have 41s left to wait.</p></article>", RateLimited},
+		{"<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>", WrongLevel},
+		{"<html>Something else</html>", Unknown},
+	}
+
+	for _, test := range tests {
+		result, _ := ParseSubmissionResponse(test.page)
+		assert.Equal(t, test.result, result, test.page)
+	}
+}
+
+func TestSubmissionLog(t *testing.T) {
+	path := t.TempDir() + "/submissions.json"
+	log, err := LoadSubmissionLog(path)
+	assert.NoError(t, err)
+	assert.NoError(t, log.Check(1, 1, "100"))
+
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 1, Answer: "100", Result: TooHigh}))
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 1, Answer: "10", Result: TooLow}))
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 1, Answer: "abc", Result: Wrong}))
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 1, Answer: "50", Result: RateLimited}))
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 2, Answer: "7", Result: WrongLevel}))
+
+	// The log is saved, and read back
+	log, err = LoadSubmissionLog(path)
+	assert.NoError(t, err)
+	assert.Len(t, log.Submissions, 5)
+
+	assert.True(t, errors.Is(log.Check(1, 1, "100"), ErrKnownWrong))
+	assert.True(t, errors.Is(log.Check(1, 1, "150"), ErrKnownWrong))
+	assert.True(t, errors.Is(log.Check(1, 1, "5"), ErrKnownWrong))
+	assert.True(t, errors.Is(log.Check(1, 1, "abc"), ErrKnownWrong))
+	assert.NoError(t, log.Check(1, 1, "50"))
+	assert.NoError(t, log.Check(1, 2, "100"))
+	assert.NoError(t, log.Check(1, 2, "7"))
+
+	_, ok := log.Answer(1, 1)
+	assert.False(t, ok)
+	assert.NoError(t, log.Add(Submission{Day: 1, Part: 1, Answer: "50", Result: Correct}))
+	assert.True(t, errors.Is(log.Check(1, 1, "51"), ErrAlreadyCorrect))
+	answer, ok := log.Answer(1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "50", answer)
+}
+
+func TestLoadPuzzlePage(t *testing.T) {
+	puzzle, err := LoadPuzzlePage("./testdata/puzzle.html")
+	assert.NoError(t, err)
+	assert.Equal(t, PuzzlePage{Day: 9, Title: "Disk Fragmenter", Example: "2333133121414131402\n"}, puzzle)
+
+	_, err = ParsePuzzlePage("<html><body>Not a puzzle</body></html>")
+	assert.True(t, errors.Is(err, ErrNoTitle))
+}
+
+func TestParsePuzzlePageMarkup(t *testing.T) {
+	puzzle, err := ParsePuzzlePage("<h2>--- Day 3: Mull It &amp; Over ---</h2><pre><code>x<em>mul(2,4)</em>&lt;\n</code></pre>")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mull It & Over", puzzle.Title)
+	assert.Equal(t, "xmul(2,4)<\n", puzzle.Example)
+}
+
+func TestFetchPuzzlePage(t *testing.T) {
+	page, _ := os.ReadFile("./testdata/puzzle.html")
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2024/day/9", r.URL.Path)
+		w.Write(page)
+	})
+
+	puzzle, err := client.FetchPuzzlePage(9)
+	assert.NoError(t, err)
+	assert.Equal(t, "Disk Fragmenter", puzzle.Title)
+}
+
+func TestLeaderboardStandings(t *testing.T) {
+	leaderboard, err := LoadLeaderboard("./testdata/leaderboard.json")
+	assert.NoError(t, err)
+	standings := leaderboard.Standings()
+	assert.Len(t, standings, 4)
+
+	// The computed scores match the website's
+	for _, standing := range standings {
+		assert.Equal(t, leaderboard.Members[strconv.Itoa(standing.ID)].LocalScore, standing.Score, standing.Name)
+	}
+	names := make([]string, len(standings))
+	for i, standing := range standings {
+		names[i] = standing.Name
+	}
+	assert.Equal(t, []string{"Alice", "Bob", "(anonymous user #3)", "Dana"}, names)
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{standings[0].Rank, standings[1].Rank, standings[2].Rank, standings[3].Rank})
+
+	alice := standings[0]
+	assert.Equal(t, 3, alice.Stars)
+	assert.Equal(t, 2, alice.DayStars(1))
+	assert.Equal(t, 1, alice.DayStars(2))
+	assert.Equal(t, 0, alice.DayStars(3))
+	assert.Equal(t, 5*time.Minute, alice.Times[0][0])
+	assert.Equal(t, 10*time.Minute, alice.Times[0][1])
+	assert.Equal(t, 1000*time.Second, alice.Times[1][0])
+	assert.Equal(t, 2*time.Hour, standings[2].Times[0][0])
+
+	_, err = ParseLeaderboard([]byte("<html>Please log in</html>"))
+	assert.ErrorContains(t, err, "invalid leaderboard")
+	_, err = ParseLeaderboard([]byte(`{"event": "next year", "members": {}}`))
+	assert.ErrorContains(t, err, "not a year")
+}
+
+func TestLeaderboardTies(t *testing.T) {
+	star := map[string]map[string]Star{"1": {"1": {Time: 1733029500, Index: 1}}}
+	leaderboard := &Leaderboard{Event: "2024", Members: map[string]Member{
+		"7": {ID: 7, Name: "Gus", Completion: map[string]map[string]Star{"1": {"1": {Time: 1733029500, Index: 2}}}},
+		"8": {ID: 8, Name: "Hal", Completion: star},
+		"9": {ID: 9, Name: "Ivy"},
+	}}
+	standings := leaderboard.Standings()
+	// Stars collected in the same second are ordered by their index
+	assert.Equal(t, "Hal", standings[0].Name)
+	assert.Equal(t, 3, standings[0].Score)
+	assert.Equal(t, 2, standings[1].Score)
+	assert.Equal(t, 3, standings[2].Rank)
+
+	star["2"] = map[string]Star{"1": {Time: 1733115600 + 30, Index: 3}}
+	leaderboard.Members["9"] = Member{ID: 9, Name: "Ivy", Completion: map[string]map[string]Star{"2": {"1": {Time: 1733115600 + 60, Index: 4}}}}
+	standings = leaderboard.Standings()
+	// Gus and Ivy have the same score, and share a rank, but Gus collected a last star first
+	assert.Equal(t, []string{"Gus", "Ivy"}, []string{standings[1].Name, standings[2].Name})
+	assert.Equal(t, 2, standings[2].Rank)
+}
+
+func TestFetchLeaderboard(t *testing.T) {
+	data, _ := os.ReadFile("./testdata/leaderboard.json")
+	client, requests := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2024/leaderboard/private/view/1.json", r.URL.Path)
+		w.Write(data)
+	})
+	address := client.LeaderboardURL(1)
+
+	leaderboard, fetched, err := client.FetchLeaderboard(address, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, leaderboard.Members, 4)
+	assert.WithinDuration(t, time.Now(), fetched, time.Minute)
+
+	// The cached copy is used until it is older than the refresh interval
+	_, _, err = client.FetchLeaderboard(address, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *requests)
+	client.MinInterval = 0
+	_, _, err = client.FetchLeaderboard(address, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *requests)
+
+	// A response which is not a leaderboard is not cached
+	other, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Please log in</html>"))
+	})
+	_, _, err = other.FetchLeaderboard(other.LeaderboardURL(1), time.Hour)
+	assert.ErrorContains(t, err, "invalid leaderboard")
+	_, statErr := os.Stat(other.LeaderboardCachePath(other.LeaderboardURL(1)))
+	assert.True(t, os.IsNotExist(statErr))
+}
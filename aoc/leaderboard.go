@@ -0,0 +1,219 @@
+package aoc
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The shortest time between downloads of a private leaderboard, as the Advent of Code maintainers ask.
+const DEFAULT_LEADERBOARD_REFRESH = 15 * time.Minute
+
+// The number of days in an Advent of Code event.
+const DAYS = 25
+
+// Puzzles unlock at midnight in the US Eastern time zone, which is always UTC-5 in December.
+var unlockZone = time.FixedZone("EST", -5*60*60)
+
+/* ------------------------- Leaderboard Definitions ------------------------ */
+
+// Leaderboard is a private leaderboard, as downloaded from the website's JSON API.
+type Leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID int               `json:"owner_id"`
+	Members map[string]Member `json:"members"`
+}
+
+// Member is a member of a private leaderboard, and the stars they have collected.
+type Member struct {
+	ID int `json:"id"`
+	// Empty for anonymous members.
+	Name       string `json:"name"`
+	Stars      int    `json:"stars"`
+	LocalScore int    `json:"local_score"`
+	LastStar   int64  `json:"last_star_ts"`
+	// When each part of each day was completed, keyed by day and then by part, e.g. {"1": {"2": ...}}.
+	Completion map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Star records when a member completed a part of a day. The index orders stars collected in the same second.
+type Star struct {
+	Time  int64 `json:"get_star_ts"`
+	Index int64 `json:"star_index"`
+}
+
+// ParseLeaderboard reads a private leaderboard from its JSON.
+func ParseLeaderboard(data []byte) (*Leaderboard, error) {
+	leaderboard := &Leaderboard{}
+	if err := json.Unmarshal(data, leaderboard); err != nil {
+		return nil, fmt.Errorf("invalid leaderboard: %w", err)
+	}
+	if _, err := leaderboard.Year(); err != nil {
+		return nil, err
+	}
+	return leaderboard, nil
+}
+
+// LoadLeaderboard reads a private leaderboard from a JSON file.
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeaderboard(data)
+}
+
+// Year returns the year of the leaderboard's event.
+func (l *Leaderboard) Year() (int, error) {
+	year, err := strconv.Atoi(l.Event)
+	if err != nil {
+		return 0, fmt.Errorf("invalid leaderboard: event %q is not a year", l.Event)
+	}
+	return year, nil
+}
+
+// DisplayName returns the member's name, or how the website shows anonymous members.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// Star returns when the member completed a part of a day, and false if they have not.
+func (m Member) Star(day, part int) (Star, bool) {
+	star, ok := m.Completion[strconv.Itoa(day)][strconv.Itoa(part)]
+	return star, ok
+}
+
+// Unlock returns the time a day's puzzle was released.
+func Unlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, unlockZone)
+}
+
+/* -------------------------------- Standings ------------------------------- */
+
+// Standing is a member's place on a leaderboard.
+type Standing struct {
+	Rank  int    `json:"rank"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	Stars int    `json:"stars"`
+	// The time taken to complete each part of each day, from the puzzle's unlock. Zero if it is not complete.
+	Times [DAYS][2]time.Duration `json:"times_ns"`
+
+	lastStar int64
+}
+
+// DayStars returns the number of stars the member has collected on a day, from 0 to 2.
+func (s Standing) DayStars(day int) int {
+	stars := 0
+	for _, elapsed := range s.Times[day-1] {
+		if elapsed > 0 {
+			stars++
+		}
+	}
+	return stars
+}
+
+// Standings returns the place of every member, computing local scores as the website does: on each part of
+// each day, the first member to complete it scores one point per member, the second one point fewer, and so on.
+// Members are ordered by score, then by stars, then by who collected their last star first. Members with the
+// same score share a rank.
+func (l *Leaderboard) Standings() []Standing {
+	year, _ := l.Year()
+	standings := make([]Standing, 0, len(l.Members))
+	index := make(map[int]int)
+	for _, member := range l.Members {
+		index[member.ID] = len(standings)
+		standings = append(standings, Standing{ID: member.ID, Name: member.DisplayName()})
+	}
+
+	for day := 1; day <= DAYS; day++ {
+		for part := 1; part <= 2; part++ {
+			// The members who completed the part, in the order they completed it
+			finishers := make([]Member, 0)
+			for _, member := range l.Members {
+				if _, ok := member.Star(day, part); ok {
+					finishers = append(finishers, member)
+				}
+			}
+			slices.SortFunc(finishers, func(a, b Member) int {
+				starA, _ := a.Star(day, part)
+				starB, _ := b.Star(day, part)
+				return cmp.Or(cmp.Compare(starA.Time, starB.Time), cmp.Compare(starA.Index, starB.Index))
+			})
+
+			for place, member := range finishers {
+				star, _ := member.Star(day, part)
+				standing := &standings[index[member.ID]]
+				standing.Score += len(l.Members) - place
+				standing.Stars++
+				standing.Times[day-1][part-1] = time.Unix(star.Time, 0).Sub(Unlock(year, day))
+				standing.lastStar = max(standing.lastStar, star.Time)
+			}
+		}
+	}
+
+	slices.SortFunc(standings, func(a, b Standing) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.Stars, a.Stars),
+			cmp.Compare(a.lastStar, b.lastStar), cmp.Compare(a.ID, b.ID))
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Score == standings[i-1].Score {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings
+}
+
+/* -------------------------- Fetching and Caching -------------------------- */
+
+// LeaderboardURL returns the address of the JSON of a private leaderboard on the website.
+func (c *Client) LeaderboardURL(id int) string {
+	return fmt.Sprintf("%s/%d/leaderboard/private/view/%d.json", strings.TrimRight(c.BaseURL, "/"), c.Year, id)
+}
+
+// LeaderboardCachePath returns the file within the data directory a leaderboard downloaded from the address is kept in.
+func (c *Client) LeaderboardCachePath(address string) string {
+	return filepath.Join(c.DataDir, fmt.Sprintf("leaderboard-%08x.json", crc32.ChecksumIEEE([]byte(address))))
+}
+
+// FetchLeaderboard returns the leaderboard at the address, and when it was downloaded. A cached copy
+// younger than the refresh interval is returned without making a request, so the leaderboard is downloaded
+// at most once per interval. Responses which are not a valid leaderboard, such as a login page, are not cached.
+func (c *Client) FetchLeaderboard(address string, refresh time.Duration) (*Leaderboard, time.Time, error) {
+	path := c.LeaderboardCachePath(address)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < refresh {
+		leaderboard, err := LoadLeaderboard(path)
+		return leaderboard, info.ModTime(), err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, address, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	body, err := c.Do(request)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	leaderboard, err := ParseLeaderboard(body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if err := os.MkdirAll(c.DataDir, 0o755); err != nil {
+		return nil, time.Time{}, err
+	}
+	return leaderboard, time.Now(), os.WriteFile(path, body, 0o644)
+}
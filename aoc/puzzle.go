@@ -0,0 +1,65 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+)
+
+/* --------------------------- Puzzle Page Parsing -------------------------- */
+
+// ErrNoTitle is returned when a page does not contain a puzzle title.
+var ErrNoTitle = errors.New("no puzzle title found")
+
+// PuzzlePage holds the details of a puzzle read from its description page.
+type PuzzlePage struct {
+	Day     int
+	Title   string
+	Example string
+}
+
+// Patterns used to read a puzzle description page.
+var (
+	titlePattern   = regexp.MustCompile(`<h2[^>]*>\s*--- Day (\d+): (.*?) ---\s*</h2>`)
+	examplePattern = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+)
+
+// ParsePuzzlePage reads the day, title and first example input from a puzzle description page.
+// The example is empty if the page has no <pre><code> block.
+func ParsePuzzlePage(page string) (PuzzlePage, error) {
+	match := titlePattern.FindStringSubmatch(page)
+	if match == nil {
+		return PuzzlePage{}, ErrNoTitle
+	}
+	puzzle := PuzzlePage{Title: html.UnescapeString(match[2])}
+	fmt.Sscan(match[1], &puzzle.Day)
+
+	if example := examplePattern.FindStringSubmatch(page); example != nil {
+		puzzle.Example = html.UnescapeString(tagPattern.ReplaceAllString(example[1], ""))
+		if !strings.HasSuffix(puzzle.Example, "\n") {
+			puzzle.Example += "\n"
+		}
+	}
+	return puzzle, nil
+}
+
+// LoadPuzzlePage reads a puzzle description page saved to a local file.
+func LoadPuzzlePage(path string) (PuzzlePage, error) {
+	page, err := os.ReadFile(path)
+	if err != nil {
+		return PuzzlePage{}, err
+	}
+	return ParsePuzzlePage(string(page))
+}
+
+// FetchPuzzlePage downloads and reads the description page for a day.
+func (c *Client) FetchPuzzlePage(day int) (PuzzlePage, error) {
+	page, err := c.Get(fmt.Sprintf("/%d/day/%d", c.Year, day))
+	if err != nil {
+		return PuzzlePage{}, err
+	}
+	return ParsePuzzlePage(string(page))
+}
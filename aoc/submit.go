@@ -0,0 +1,181 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/* --------------------------- Submission Results --------------------------- */
+
+// Result is the website's verdict on a submitted answer.
+type Result string
+
+const (
+	Correct     Result = "right"
+	Wrong       Result = "wrong"
+	TooHigh     Result = "too-high"
+	TooLow      Result = "too-low"
+	RateLimited Result = "rate-limited"
+	WrongLevel  Result = "wrong-level"
+	Unknown     Result = "unknown"
+)
+
+// IsWrong returns true if the result means the answer was incorrect.
+func (r Result) IsWrong() bool {
+	return r == Wrong || r == TooHigh || r == TooLow
+}
+
+// ErrKnownWrong is returned when submitting an answer which is already known to be wrong.
+var ErrKnownWrong = errors.New("answer is known to be wrong")
+
+// ErrAlreadyCorrect is returned when submitting an answer for a part which has already been solved.
+var ErrAlreadyCorrect = errors.New("part has already been solved")
+
+// Submission records an answer submitted to the website, and the result.
+type Submission struct {
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Result  Result    `json:"result"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+/* ---------------------------- Answer Submission --------------------------- */
+
+// SubmitAnswer posts an answer for a day and part to the website, and returns the parsed result.
+func (c *Client) SubmitAnswer(day, part int, answer string) (Submission, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimRight(c.BaseURL, "/"), c.Year, day)
+
+	request, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Submission{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.Do(request)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	result, message := ParseSubmissionResponse(string(body))
+	return Submission{Day: day, Part: part, Answer: answer, Result: result, Message: message, Time: time.Now()}, nil
+}
+
+// Patterns used to read the response page for a submitted answer.
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]+>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+)
+
+// ParseSubmissionResponse reads the result of a submission from the website's response page.
+// The message is the text of the response's main article, with any markup removed.
+func ParseSubmissionResponse(page string) (Result, string) {
+	message := page
+	if match := articlePattern.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spacePattern.ReplaceAllString(message, " "))
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		return Correct, message
+	case strings.Contains(message, "You gave an answer too recently"):
+		return RateLimited, message
+	// Sent both when the part is already solved and when part 2 is not yet unlocked, so it is not final
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		return WrongLevel, message
+	case strings.Contains(message, "your answer is too high"):
+		return TooHigh, message
+	case strings.Contains(message, "your answer is too low"):
+		return TooLow, message
+	case strings.Contains(message, "That's not the right answer"):
+		return Wrong, message
+	}
+	return Unknown, message
+}
+
+/* ------------------ SubmissionLog Definition and Methods ------------------ */
+
+// SubmissionLog is a record of every answer submitted, stored as JSON at Path.
+type SubmissionLog struct {
+	Path        string
+	Submissions []Submission
+}
+
+// LoadSubmissionLog reads the submission log at path. A missing file is treated as an empty log.
+func LoadSubmissionLog(path string) (*SubmissionLog, error) {
+	log := &SubmissionLog{Path: path, Submissions: make([]Submission, 0)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return log, json.Unmarshal(data, &log.Submissions)
+}
+
+// Check returns an error if the answer should not be submitted, because the part has already been
+// solved, the answer has already been rejected, or it lies outside a range the website has reported.
+func (l *SubmissionLog) Check(day, part int, answer string) error {
+	value, valueErr := strconv.Atoi(answer)
+
+	for _, s := range l.Submissions {
+		if s.Day != day || s.Part != part {
+			continue
+		}
+		if s.Result == Correct {
+			return fmt.Errorf("%w: day %d part %d", ErrAlreadyCorrect, day, part)
+		}
+		if s.Result.IsWrong() && s.Answer == answer {
+			return fmt.Errorf("%w: %s was %s", ErrKnownWrong, answer, s.Result)
+		}
+
+		previous, err := strconv.Atoi(s.Answer)
+		if valueErr != nil || err != nil {
+			continue
+		}
+		if (s.Result == TooHigh && value >= previous) || (s.Result == TooLow && value <= previous) {
+			return fmt.Errorf("%w: %s was %s", ErrKnownWrong, s.Answer, s.Result)
+		}
+	}
+	return nil
+}
+
+// Answer returns the answer the website accepted for a day and part, if there is one.
+func (l *SubmissionLog) Answer(day, part int) (string, bool) {
+	for _, s := range l.Submissions {
+		if s.Day == day && s.Part == part && s.Result == Correct {
+			return s.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Add appends a submission to the log and saves it.
+func (l *SubmissionLog) Add(s Submission) error {
+	l.Submissions = append(l.Submissions, s)
+
+	data, err := json.MarshalIndent(l.Submissions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(l.Path, data, 0o644)
+}
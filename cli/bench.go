@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/parallel"
+)
+
+/* ------------------------------ Bench Command ------------------------------- */
+
+// Benchmark is the time taken to solve a day over several runs.
+// Benchmarks of generated inputs also record the size of the input.
+type Benchmark struct {
+	Day  int           `json:"day"`
+	Size int           `json:"size,omitempty"`
+	Runs int           `json:"runs"`
+	Min  time.Duration `json:"min_ns"`
+	Mean time.Duration `json:"mean_ns"`
+	Max  time.Duration `json:"max_ns"`
+}
+
+// Bench solves one or all days repeatedly and reports the fastest, mean and slowest times.
+func Bench(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("bench", summary("bench"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to benchmark.")
+	count := flags.Int("count", 10, "The number of times to solve each day.")
+	scale := flags.String("scale", "", "Benchmark generated inputs of these comma separated sizes, e.g. 1000,2000,4000, instead of the puzzle input.")
+	seed := flags.Uint64("seed", DEFAULT_SEED, "The seed of the inputs generated with -scale.")
+	parseOptions(flags, &options, argv)
+
+	if *scale != "" {
+		BenchScaling(parseSizes(*scale), *seed, max(*count, 1), &options)
+		return
+	}
+	for _, day := range selectOptionDays(&options) {
+		Solver := solution.Solutions[dayPath(day)]
+		ctx := DayContext(context.Background(), day, Solver.Solution, &options)
+		input := ReadInput(day, Solver, &options)
+		benchmark := BenchSolution(ctx, day, Solver.Solution, input, max(*count, 1))
+
+		if options.Format == config.JSON {
+			line, _ := json.Marshal(benchmark)
+			fmt.Fprintln(Stdout, string(line))
+			continue
+		}
+		fmt.Fprintf(Stdout, "Day %2d  %s\t%-24s min %-12v mean %-12v max %v\n",
+			day, Solver.Icon, Solver.Title, benchmark.Min, benchmark.Mean, benchmark.Max)
+	}
+}
+
+// BenchSolution solves the puzzle the given number of times, and returns the times taken.
+// The context carries the puzzle's parameters.
+func BenchSolution(ctx context.Context, day int, Solver solution.Solution, input string, runs int) Benchmark {
+	benchmark := Benchmark{Day: day, Runs: runs}
+	var total time.Duration
+
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		solution.Solve(ctx, Solver, input)
+		elapsed := time.Since(start)
+
+		total += elapsed
+		if i == 0 || elapsed < benchmark.Min {
+			benchmark.Min = elapsed
+		}
+		benchmark.Max = max(benchmark.Max, elapsed)
+	}
+	benchmark.Mean = total / time.Duration(runs)
+	return benchmark
+}
+
+/* ----------------------------- Scaling Benchmark ---------------------------- */
+
+// The width of the longest bar in a scaling plot.
+const PLOT_WIDTH = 40
+
+// BenchScaling solves generated inputs of each size for one or all days, and plots how the mean time
+// grows with the size of the input. With "all", the days without a generator are skipped.
+func BenchScaling(sizes []int, seed uint64, runs int, options *config.Options) {
+	for _, day := range selectOptionDays(options) {
+		Solver := solution.Solutions[dayPath(day)]
+		if _, ok := Solver.Solution.(solution.Generator); !ok {
+			if options.Day != "all" {
+				log.Fatalf("Day %d has no input generator.\n", day)
+			}
+			continue
+		}
+
+		benchmarks := make([]Benchmark, len(sizes))
+		for i, size := range sizes {
+			input, overrides := GenerateInput(day, Solver.Solution, size, seed)
+			// Parameters given by the user win, so that e.g. the seconds of day 14 can still be changed
+			merged := options.ParamsFor(day)
+			for name, value := range overrides {
+				if _, ok := merged[name]; !ok {
+					merged[name] = value
+				}
+			}
+			ctx, err := solution.WithParams(parallel.WithWorkers(context.Background(), options.Workers), Solver.Solution, merged)
+			if err != nil {
+				log.Fatalf("Day %d: %v\n", day, err)
+			}
+
+			benchmarks[i] = BenchSolution(ctx, day, Solver.Solution, input, runs)
+			benchmarks[i].Size = size
+		}
+
+		if options.Format == config.JSON {
+			for _, benchmark := range benchmarks {
+				line, _ := json.Marshal(benchmark)
+				fmt.Fprintln(Stdout, string(line))
+			}
+			continue
+		}
+		fmt.Fprintf(Stdout, "Day %2d  %s\t%s\n", day, Solver.Icon, Solver.Title)
+		PlotScaling(benchmarks)
+	}
+}
+
+// PlotScaling prints a bar for the mean time of each benchmark, and the rate at which it grows with size.
+func PlotScaling(benchmarks []Benchmark) {
+	slowest := time.Duration(1)
+	for _, benchmark := range benchmarks {
+		slowest = max(slowest, benchmark.Mean)
+	}
+	for _, benchmark := range benchmarks {
+		bar := int(math.Round(float64(benchmark.Mean) / float64(slowest) * PLOT_WIDTH))
+		fmt.Fprintf(Stdout, "\tsize %8d  mean %-12v %s\n", benchmark.Size, benchmark.Mean, strings.Repeat("█", max(bar, 1)))
+	}
+	if exponent, ok := GrowthExponent(benchmarks); ok {
+		fmt.Fprintf(Stdout, "\tgrows as size^%.2f\n", exponent)
+	}
+}
+
+// GrowthExponent estimates k, where the mean time grows as size^k, by fitting a line to the log of the
+// mean times against the log of the sizes. At least two different sizes are needed.
+func GrowthExponent(benchmarks []Benchmark) (float64, bool) {
+	var n, sumX, sumY, sumXX, sumXY float64
+	for _, benchmark := range benchmarks {
+		if benchmark.Size <= 0 || benchmark.Mean <= 0 {
+			continue
+		}
+		x, y := math.Log(float64(benchmark.Size)), math.Log(float64(benchmark.Mean))
+		n, sumX, sumY, sumXX, sumXY = n+1, sumX+x, sumY+y, sumXX+x*x, sumXY+x*y
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator < 1e-9 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
+
+// parseSizes parses a comma separated list of input sizes. Sizes which are not positive integers are fatal.
+func parseSizes(list string) []int {
+	sizes := make([]int, 0)
+	for _, field := range strings.Split(list, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size <= 0 {
+			log.Fatalf("Invalid size %q in -scale. Expected positive integers, e.g. 1000,2000,4000.\n", field)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
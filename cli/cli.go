@@ -0,0 +1,176 @@
+// Package cli implements the runner's commands, such as run, bench and submit.
+// Each command has its own flags, in addition to the options shared by every command.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/isolate"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/trace"
+)
+
+// Stdout is where commands write their output.
+var Stdout io.Writer = os.Stdout
+
+/* ------------------------------- Command Table ------------------------------ */
+
+// Command is a subcommand of the runner, such as "run" or "bench".
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(argv []string)
+}
+
+// Commands lists every command, in the order they are shown in help text.
+var Commands []Command
+
+// The commands look up their own help text in Commands, so the table is built at init time.
+func init() {
+	Commands = []Command{
+		{"run", "Solve one or all days and print the answers.", Run},
+		{"bench", "Solve days repeatedly and report how long they take.", Bench},
+		{"test", "Run the unit tests for one or all days.", RunTests},
+		{"list", "List every registered day with its icon and title.", List},
+		{"tui", "Browse and run the days in a full-screen dashboard.", Dashboard},
+		{"new", "Create the package for a new day from the puzzle description.", New},
+		{"verify", "Check answers against the known correct answers in the answer store.", Verify},
+		{"compare", "Solve days with every variant of their solution and compare the answers.", Compare},
+		{"generate", "Write a synthetic puzzle input of any size for a day.", Generate},
+		{"check-determinism", "Solve days several times and report any whose output differs.", CheckDeterminism},
+		{"submit", "Submit an answer to the Advent of Code website.", Submit},
+		{"leaderboard", "Show the standings of a private leaderboard.", Leaderboard},
+		{"serve", "Serve an HTTP API for solving and rendering puzzles.", Serve},
+	}
+}
+
+// Main runs the command named by the first argument.
+// With no command, or when the first argument is a flag (e.g. -day 5), the run command is used.
+func Main(argv []string) {
+	if len(argv) == 0 || (strings.HasPrefix(argv[0], "-") && !isHelp(argv[0])) {
+		Run(argv)
+		return
+	}
+	if argv[0] == isolate.CHILD_COMMAND {
+		ServeIsolated()
+		return
+	}
+	if isHelp(argv[0]) {
+		usage(Stdout)
+		return
+	}
+
+	for _, command := range Commands {
+		if command.Name == argv[0] {
+			command.Run(argv[1:])
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command %q.\n\n", argv[0])
+	usage(os.Stderr)
+	os.Exit(2)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// usage prints the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: go run main.go <command> [flags]\n\nCommands:\n")
+	for _, command := range Commands {
+		fmt.Fprintf(w, "  %-18s %s\n", command.Name, command.Summary)
+	}
+	fmt.Fprintf(w, "\nRun \"go run main.go <command> -h\" for the flags of a command.\n")
+	fmt.Fprintf(w, "\"go run main.go -day 5\" is short for \"go run main.go run -day 5\".\n")
+}
+
+// isHelp returns true if the argument asks for help.
+func isHelp(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+// newFlagSet returns a flag set for a command, with the shared options bound to it.
+func newFlagSet(name, summary string, options *config.Options) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: go run main.go %s [flags]\n\n%s\n\nFlags:\n", name, summary)
+		flags.PrintDefaults()
+	}
+	options.Bind(flags)
+	return flags
+}
+
+// parseOptions parses a command's flags into the options, and applies any process wide options.
+func parseOptions(flags *flag.FlagSet, options *config.Options, argv []string) {
+	if err := options.Parse(flags, argv, os.Getenv); err != nil {
+		log.Fatal(err)
+	}
+	if options.Workers > 0 {
+		runtime.GOMAXPROCS(options.Workers)
+	}
+	if err := trace.Setup(options.Trace()); err != nil {
+		log.Fatal(err)
+	}
+	if err := registerVariants(options); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// summary returns the summary of the named command.
+func summary(name string) string {
+	for _, command := range Commands {
+		if command.Name == name {
+			return command.Summary
+		}
+	}
+	return ""
+}
+
+// selectDays returns the days chosen by a -day flag, which is either a day number or "all".
+func selectDays(day string) []int {
+	if day == "all" {
+		days := make([]int, 0, len(solution.Solutions))
+		for path := range solution.Solutions {
+			days = append(days, util.AtoI(strings.TrimPrefix(path, "day-")))
+		}
+		slices.Sort(days)
+		return days
+	}
+
+	n, err := strconv.Atoi(day)
+	if _, ok := solution.Solutions[dayPath(n)]; err != nil || !ok {
+		log.Fatalf("Invalid day specified. No solution exists for day %s.\n", day)
+	}
+	return []int{n}
+}
+
+// selectOptionDays returns the days chosen by the options' -day flag.
+// Parameters given with -param only make sense for a single day, so they are fatal with "all".
+func selectOptionDays(options *config.Options) []int {
+	days := selectDays(options.Day)
+	if len(days) > 1 && len(options.Params) > 0 {
+		log.Fatal("Puzzle parameters can only be set with -param when a single -day is chosen.")
+	}
+	if isVariant(options.Variant) && options.Day == "all" {
+		// Only the days with the variant are run
+		days = slices.DeleteFunc(days, func(day int) bool {
+			_, ok := solution.Variant(dayPath(day), options.Variant)
+			return !ok
+		})
+	}
+	return days
+}
+
+// dayPath returns the name of a day's package and input file, e.g. day-05.
+func dayPath(day int) string {
+	return fmt.Sprintf("day-%02d", day)
+}
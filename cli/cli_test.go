@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/determinism"
+	"shaneholland.dev/aoc-2024/isolate"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+)
+
+// Captures everything written to Stdout while the function runs.
+func captureOutput(f func()) string {
+	var out bytes.Buffer
+	original := Stdout
+	Stdout = &out
+	defer func() { Stdout = original }()
+	f()
+	return out.String()
+}
+
+func TestSelectDays(t *testing.T) {
+	days := selectDays("all")
+	assert.Len(t, days, len(solution.Solutions))
+	assert.Equal(t, 1, days[0])
+	assert.Equal(t, []int{5}, selectDays("5"))
+	assert.Equal(t, "day-05", dayPath(5))
+}
+
+func TestList(t *testing.T) {
+	output := captureOutput(func() { List([]string{"-format", "text"}) })
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	assert.Len(t, lines, len(solution.Solutions))
+	assert.Equal(t, "Day  1  🕵\tHistorian Hysteria", lines[0])
+	assert.Equal(t, "Day  6  💂\tGuard Gallivant 🎨 🎞️", lines[5])
+}
+
+func TestServe(t *testing.T) {
+	options := config.Defaults()
+	server := httptest.NewServer(Handler(&options))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/days")
+	assert.NoError(t, err)
+	listings := make([]Listing, 0)
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&listings))
+	assert.Equal(t, Listings(), listings)
+
+	input := util.ReadFile("../solution/day-01/test-data.txt")
+	response, err = http.Post(server.URL+"/days/1", "text/plain", strings.NewReader(input))
+	assert.NoError(t, err)
+	result := Result{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, answer.FromInt(11), result.Part1)
+	assert.Equal(t, answer.FromInt(31), result.Part2)
+
+	// Day 1 cannot be rendered, and day 99 does not exist
+	response, _ = http.Post(server.URL+"/days/1/render", "text/plain", strings.NewReader(input))
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	response, _ = http.Post(server.URL+"/days/99", "text/plain", strings.NewReader(input))
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	response, _ = http.Post(server.URL+"/days/1", "text/plain", strings.NewReader(""))
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// Malformed input fails the answers, rather than the server
+	response, err = http.Post(server.URL+"/days/1", "text/plain", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+	result = Result{}
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Contains(t, result.Error, "no match found for line 1")
+	assert.Equal(t, answer.Answer{}, result.Part1)
+	response, _ = http.Post(server.URL+"/days/6/render", "text/plain", strings.NewReader("hello world"))
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+
+	input = util.ReadFile("../solution/day-06/test-data.txt")
+	response, _ = http.Post(server.URL+"/days/6/render", "text/plain", strings.NewReader(input))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "image/png", response.Header.Get("Content-Type"))
+
+	// Puzzle parameters are read from the query string
+	input = util.ReadFile("../solution/day-18/test-data.txt")
+	response, _ = http.Post(server.URL+"/days/18?size=7&bytes=12", "text/plain", strings.NewReader(input))
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, answer.FromInt(22), result.Part1)
+	assert.Equal(t, answer.FromPoint(util.Point{X: 6, Y: 1}), result.Part2)
+	response, _ = http.Post(server.URL+"/days/18?size=seven", "text/plain", strings.NewReader(input))
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+// A solution which ignores its context, and takes a while to solve any input.
+type slowSolution struct{}
+
+func (slowSolution) Solve(string) (string, string) {
+	time.Sleep(200 * time.Millisecond)
+	return "1", "2"
+}
+
+// A solution which panics on any input.
+type panicSolution struct{}
+
+func (panicSolution) Solve(string) (string, string) {
+	panic("integer divide by zero")
+}
+
+func TestSolve(t *testing.T) {
+	_, _, err := Solve(context.Background(), slowSolution{}, "", 10*time.Millisecond)
+	assert.ErrorIs(t, err, ErrTimedOut)
+	assert.EqualError(t, err, "timed out after 10ms")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = Solve(ctx, slowSolution{}, "", time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrTimedOut)
+
+	// An abandoned solver is only done once it returns
+	done := make(chan struct{})
+	_, _, err = solveThen(context.Background(), slowSolution{}, "", 10*time.Millisecond, func() { close(done) })
+	assert.ErrorIs(t, err, ErrTimedOut)
+	select {
+	case <-done:
+		t.Fatal("the solver was done before it returned")
+	default:
+	}
+	<-done
+
+	answer1, answer2, err := Solve(context.Background(), slowSolution{}, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", answer1.String())
+	assert.Equal(t, "2", answer2.String())
+
+	// A panic fails both parts, rather than the whole program
+	answer1, answer2, err = Solve(context.Background(), panicSolution{}, "", time.Second)
+	assert.NoError(t, err)
+	assert.EqualError(t, answer1.Err(), "panic: integer divide by zero")
+	assert.EqualError(t, answer2.Err(), "panic: integer divide by zero")
+}
+
+func TestVerifyAnswer(t *testing.T) {
+	submissions := &aoc.SubmissionLog{Submissions: []aoc.Submission{
+		{Day: 1, Part: 1, Answer: "10", Result: aoc.TooLow},
+		{Day: 1, Part: 1, Answer: "11", Result: aoc.Correct},
+	}}
+
+	ok := true
+	output := captureOutput(func() {
+		ok = VerifyAnswer(submissions, 1, 1, "", answer.Parse("011")) && ok
+		assert.False(t, VerifyAnswer(submissions, 1, 1, solution.BUILTIN, answer.FromInt(12)))
+		ok = VerifyAnswer(submissions, 1, 2, "", answer.FromInt(31)) && ok
+		assert.False(t, VerifyAnswer(submissions, 1, 2, "", answer.FromError(answer.ErrOverflow)))
+		ok = VerifyAnswer(submissions, 1, 1, "alice", answer.FromInt(11)) && ok
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "✅ Day 1 Part 1: 11\n❌ Day 1 Part 1: got 12, expected 11\n❔ Day 1 Part 2: 31 (no known answer)\n"+
+		"❌ Day 1 Part 2: integer overflow\n✅ Day 1 [alice] Part 1: 11\n", output)
+}
+
+func TestBenchSolution(t *testing.T) {
+	input := util.ReadFile("../solution/day-01/test-data.txt")
+	benchmark := BenchSolution(context.Background(), 1, solution.Solutions["day-01"].Solution, input, 5)
+	assert.Equal(t, 5, benchmark.Runs)
+	assert.LessOrEqual(t, benchmark.Min, benchmark.Mean)
+	assert.LessOrEqual(t, benchmark.Mean, benchmark.Max)
+}
+
+func TestPrintJSON(t *testing.T) {
+	output := captureOutput(func() {
+		PrintJSON(NewResult(18, solution.Solutions["day-18"], answer.FromInt(22), answer.FromPoint(util.Point{X: 6, Y: 1}), time.Second, nil))
+		PrintJSON(NewResult(11, solution.Solutions["day-11"], answer.FromInt(55312), answer.FromError(answer.ErrOverflow), time.Second, nil))
+	})
+	assert.Equal(t, `{"day":18,"title":"RAM Run","part1":22,"part2":"6,1","time":"1s"}`+"\n"+
+		`{"day":11,"title":"Plutonian Pebbles","part1":55312,"part2":null,"time":"1s","error":"part 2: integer overflow"}`+"\n", output)
+}
+
+func TestPrintDeterminism(t *testing.T) {
+	Solver := solution.Solutions["day-08"]
+	output := captureOutput(func() {
+		PrintDeterminism(8, Solver, determinism.Report{Outputs: make([]determinism.Output, 5)}, config.TEXT)
+		PrintDeterminism(8, Solver, determinism.Report{
+			Outputs:     make([]determinism.Output, 2),
+			Differences: []string{"part 1: run 1 gave 14, run 2 (GOMAXPROCS=2) gave 13"},
+		}, config.JSON)
+	})
+	assert.Equal(t, "✅ Day  8  📡\tResonant Collinearity: 5 runs agree\n"+
+		`{"day":8,"runs":2,"deterministic":false,"differences":["part 1: run 1 gave 14, run 2 (GOMAXPROCS=2) gave 13"]}`+"\n", output)
+}
+
+func TestPrintIsolatedSummary(t *testing.T) {
+	output := captureOutput(func() {
+		PrintIsolatedSummary([]int{8, 9}, []isolate.Outcome{
+			{Status: isolate.OK},
+			{Status: isolate.OutOfMemory, Err: errors.New("runtime: out of memory")},
+		})
+	})
+	assert.Equal(t, "📋 Summary\n"+
+		"✅ Day  8  📡\tResonant Collinearity: ok\n"+
+		"💾 Day  9  💾\tDisk Fragmenter: out of memory (runtime: out of memory)\n", output)
+}
+
+func TestGrowthExponent(t *testing.T) {
+	exponent, ok := GrowthExponent([]Benchmark{
+		{Size: 100, Mean: time.Millisecond},
+		{Size: 200, Mean: 4 * time.Millisecond},
+		{Size: 400, Mean: 16 * time.Millisecond},
+	})
+	assert.True(t, ok)
+	assert.InDelta(t, 2.0, exponent, 1e-9)
+
+	_, ok = GrowthExponent([]Benchmark{{Size: 100, Mean: time.Millisecond}})
+	assert.False(t, ok)
+}
+
+// A variant of day 1 which only gets part 1 right.
+type halfRight struct{}
+
+func (halfRight) Solve(input string) (string, string) {
+	return "11", "0"
+}
+
+func TestCompareVariants(t *testing.T) {
+	assert.NoError(t, solution.RegisterVariant("day-01", "half", halfRight{}))
+	defer delete(solution.Variants, "day-01")
+	assert.Equal(t, []string{"half"}, Listings()[0].Variants)
+
+	options := config.Defaults()
+	options.Days["1"] = config.DayOptions{Input: "../solution/day-01/test-data.txt"}
+	comparisons := CompareVariants(1, solution.VariantNames("day-01"), &options)
+	assert.Len(t, comparisons, 2)
+	assert.Equal(t, "builtin", comparisons[0].Variant)
+	assert.True(t, comparisons[0].Agrees)
+	assert.Equal(t, "half", comparisons[1].Variant)
+	assert.False(t, comparisons[1].Agrees)
+
+	output := captureOutput(func() { PrintComparisons(1, comparisons) })
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "🎄 Day 1: Historian Hysteria 🕵", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "\t✅ builtin  part 1: 11  part 2: 31 ("), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "\t❌ half     part 1: 11  part 2: 0 ("), lines[2])
+}
+
+func TestLeaderboard(t *testing.T) {
+	data := util.ReadFile("../aoc/testdata/leaderboard.json")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(data))
+	}))
+	defer server.Close()
+	t.Setenv(aoc.SESSION_ENV, "test-session")
+
+	argv := []string{"-url", server.URL + "/leaderboard.json", "-data-dir", t.TempDir(), "-refresh", "1h", "-day", "1", "-format", "text"}
+	output := captureOutput(func() { Leaderboard(argv) })
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "🏆 Private Leaderboard [2024], updated"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "     Name                Score Stars  🕵 🦌 🧮"), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "  1) Alice                  11     3  ★  ☆  ·"), lines[2])
+	assert.True(t, strings.HasPrefix(lines[4], "  3) (anonymous user #3)     2     1  ☆  ·"), lines[4])
+	assert.Equal(t, "⏱️ Day 1: Historian Hysteria 🕵", lines[7])
+	assert.Equal(t, "\tBob                  part 1: 6m40s      part 2: 8m20s", lines[8])
+	assert.Equal(t, "\t(anonymous user #3)  part 1: 2h0m0s     part 2: -", lines[10])
+
+	// The cached copy is used within the refresh interval
+	captureOutput(func() { Leaderboard(argv) })
+	assert.Equal(t, 1, requests)
+}
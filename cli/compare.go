@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/external"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* ----------------------------- Compare Command ----------------------------- */
+
+// Comparison is the result of solving a day with one variant of its solution, and whether its answers
+// agree with those of the built-in solution.
+type Comparison struct {
+	Result
+	Agrees bool `json:"agrees"`
+}
+
+// Compare solves one or all days with every variant of their solution, such as a teammate's, and reports
+// the answers and time of each, and whether they agree with the built-in solution. With "all", days without
+// variants are skipped. The process exits with a failure status if any variant disagrees or fails.
+func Compare(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("compare", summary("compare"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to compare the variants of.")
+	parseOptions(flags, &options, argv)
+
+	failed := false
+	for _, day := range selectOptionDays(&options) {
+		names := solution.VariantNames(dayPath(day))
+		if len(names) == 1 && options.Day == "all" {
+			continue
+		}
+
+		comparisons := CompareVariants(day, names, &options)
+		for _, comparison := range comparisons {
+			failed = failed || !comparison.Agrees
+		}
+		if options.Format == config.JSON {
+			for _, comparison := range comparisons {
+				line, _ := json.Marshal(comparison)
+				fmt.Fprintln(Stdout, string(line))
+			}
+			continue
+		}
+		PrintComparisons(day, comparisons)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// CompareVariants solves a day with each of the named variants of its solution, in order. The answers of
+// every variant are compared with those of the first, which is normally the built-in solution.
+func CompareVariants(day int, names []string, options *config.Options) []Comparison {
+	comparisons := make([]Comparison, len(names))
+	for i, name := range names {
+		Solver := solverFor(day, name)
+		ctx := DayContext(context.Background(), day, Solver.Solution, options)
+		input := ReadInput(day, Solver, options)
+		start := time.Now()
+		answer1, answer2, err := Solve(ctx, Solver.Solution, input, options.TimeoutFor(day))
+
+		result := NewResult(day, Solver, answer1, answer2, time.Since(start), err)
+		result.Variant = name
+		reference := result
+		if i > 0 {
+			reference = comparisons[0].Result
+		}
+		agrees := result.Error == "" && answer1.Equal(reference.Part1) && answer2.Equal(reference.Part2)
+		comparisons[i] = Comparison{Result: result, Agrees: agrees}
+	}
+	return comparisons
+}
+
+// PrintComparisons prints the answers and time of each variant of a day's solution, one line per variant.
+func PrintComparisons(day int, comparisons []Comparison) {
+	Solver := solution.Solutions[dayPath(day)]
+	fmt.Fprintf(Stdout, "🎄 Day %d: %s %s\n", day, Solver.Title, Solver.Icon)
+
+	width := 0
+	for _, comparison := range comparisons {
+		width = max(width, len(comparison.Variant))
+	}
+	for _, comparison := range comparisons {
+		icon := "✅"
+		if !comparison.Agrees {
+			icon = "❌"
+		}
+		if comparison.Error != "" {
+			fmt.Fprintf(Stdout, "\t%s %-*s  %s (%s)\n", icon, width, comparison.Variant, comparison.Error, comparison.Time)
+			continue
+		}
+		fmt.Fprintf(Stdout, "\t%s %-*s  part 1: %s  part 2: %s (%s)\n",
+			icon, width, comparison.Variant, comparison.Part1, comparison.Part2, comparison.Time)
+	}
+}
+
+/* -------------------------------- Variants -------------------------------- */
+
+// registerVariants loads the variants configured for each day and registers them, so that every command
+// can find them by name.
+func registerVariants(options *config.Options) error {
+	for key, dayOptions := range options.Days {
+		if len(dayOptions.Variants) == 0 {
+			continue
+		}
+		day, err := strconv.Atoi(key)
+		if err != nil {
+			return fmt.Errorf("invalid day %q in the config file", key)
+		}
+		for name, variant := range dayOptions.Variants {
+			s, err := loadVariant(day, variant)
+			if err != nil {
+				return fmt.Errorf("variant %q of day %d: %w", name, day, err)
+			}
+			if err := solution.RegisterVariant(dayPath(day), name, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadVariant returns the external solution a variant describes. A program is sent the parameters of the
+// day's built-in solution, while a plugin declares its own.
+func loadVariant(day int, variant config.VariantOptions) (solution.Solution, error) {
+	if variant.Plugin != "" {
+		return external.LoadPlugin(variant.Plugin)
+	}
+	process := external.Process{Day: day, Command: variant.Command}
+	if parameterized, ok := solution.Solutions[dayPath(day)].Solution.(solution.Parameterized); ok {
+		process.Declared = parameterized.Params()
+	}
+	return process, nil
+}
+
+// solverFor returns the named variant of a day's solution. The empty name is the built-in solution.
+// A variant the day does not have is fatal.
+func solverFor(day int, variant string) solution.Solver {
+	Solver, ok := solution.Variant(dayPath(day), variant)
+	if !ok {
+		log.Fatalf("Day %d has no variant %q.\n", day, variant)
+	}
+	return Solver
+}
+
+// isVariant returns true if the name is a variant other than the built-in solution.
+func isVariant(name string) bool {
+	return name != "" && name != solution.BUILTIN
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"log"
+	"os"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/tui"
+)
+
+/* ---------------------------- Dashboard Command ----------------------------- */
+
+// Dashboard shows a full-screen dashboard for browsing and running the days.
+// When the output is not a terminal, every day is run as by the run command instead.
+func Dashboard(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("tui", summary("tui"), &options)
+	parseOptions(flags, &options, argv)
+
+	if !tui.IsTerminal(os.Stdout) || !tui.IsTerminal(os.Stdin) {
+		Run(argv)
+		return
+	}
+
+	restore, err := tui.MakeRaw(os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	width, height := tui.Size(os.Stdin)
+	app := tui.NewApp(os.Stdout, os.Stdin, width, height, func(day int) (string, error) {
+		data, err := os.ReadFile(options.InputPath(day))
+		if err != nil {
+			return "", err
+		}
+		return solution.NormalizeInput(solution.Solutions[dayPath(day)].Solution, string(data)), nil
+	})
+	app.Params = options.ParamsFor
+	// The terminal is restored before any error is reported, as log.Fatal skips deferred calls
+	err = app.Run()
+	restore()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
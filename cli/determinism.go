@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/determinism"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* ------------------------- Check Determinism Command ------------------------ */
+
+// DeterminismResult is the outcome of checking a day, as reported in JSON output.
+type DeterminismResult struct {
+	Day           int      `json:"day"`
+	Runs          int      `json:"runs"`
+	Deterministic bool     `json:"deterministic"`
+	Differences   []string `json:"differences,omitempty"`
+}
+
+// CheckDeterminism solves one or all days several times, and reports any day whose answers,
+// visualization or animation differ between runs. The process exits with a failure status if any do.
+func CheckDeterminism(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("check-determinism", summary("check-determinism"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to check.")
+	runs := flags.Int("runs", determinism.DEFAULT_RUNS, "The number of times to solve each day.")
+	varyProcs := flags.Bool("vary-procs", false, "Solve each run with a different GOMAXPROCS (1, 2 and the number of CPUs).")
+	flags.IntVar(&options.MaxFrames, "max-frames", determinism.DEFAULT_MAX_FRAMES, "The number of animation frames to compare in each run (0 for no limit).")
+	parseOptions(flags, &options, argv)
+
+	failed := false
+	for _, day := range selectOptionDays(&options) {
+		Solver := solution.Solutions[dayPath(day)]
+		ctx := DayContext(context.Background(), day, Solver.Solution, &options)
+		input := ReadInput(day, Solver, &options)
+		report := determinism.Check(ctx, Solver.Solution, input, determinism.Options{
+			Runs:      *runs,
+			VaryProcs: *varyProcs,
+			MaxFrames: options.MaxFrames,
+		})
+		failed = failed || !report.Deterministic()
+		PrintDeterminism(day, Solver, report, options.Format)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// PrintDeterminism prints the report of checking a day, as text or a single line of JSON.
+func PrintDeterminism(day int, Solver solution.Solver, report determinism.Report, format string) {
+	if format == config.JSON {
+		result := DeterminismResult{day, len(report.Outputs), report.Deterministic(), report.Differences}
+		line, _ := json.Marshal(result)
+		fmt.Fprintln(Stdout, string(line))
+		return
+	}
+
+	if report.Deterministic() {
+		fmt.Fprintf(Stdout, "✅ Day %2d  %s\t%s: %d runs agree\n", day, Solver.Icon, Solver.Title, len(report.Outputs))
+		return
+	}
+	fmt.Fprintf(Stdout, "❌ Day %2d  %s\t%s: output differs between runs\n", day, Solver.Icon, Solver.Title)
+	for _, difference := range report.Differences {
+		fmt.Fprintf(Stdout, "\t%s\n", difference)
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* ----------------------------- Generate Command ----------------------------- */
+
+// The seed used for generated inputs when none is given.
+const DEFAULT_SEED = 2024
+
+// Generate writes a synthetic puzzle input of a given size for a day, to stdout or a file.
+// The parameters the input must be solved with are printed separately, so stdout can be redirected to a file.
+func Generate(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("generate", summary("generate"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to generate an input for.")
+	size := flags.Int("size", 0, "The size of the input, such as the width of a grid or the number of lines. See each day's Generate method.")
+	seed := flags.Uint64("seed", DEFAULT_SEED, "The seed of the random input. The same seed always gives the same input.")
+	out := flags.String("out", "", "The file to write the input to. Defaults to stdout.")
+	parseOptions(flags, &options, argv)
+
+	days := selectOptionDays(&options)
+	if len(days) != 1 {
+		log.Fatal("Choose a single -day to generate an input for.")
+	}
+	if *size <= 0 {
+		log.Fatal("The -size of the input must be positive.")
+	}
+
+	day := days[0]
+	Solver := solution.Solutions[dayPath(day)]
+	input, overrides := GenerateInput(day, Solver.Solution, *size, *seed)
+
+	if *out == "" {
+		fmt.Fprintln(Stdout, input)
+	} else {
+		if err := os.WriteFile(*out, []byte(input+"\n"), 0644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(Stdout, "📝 Day %d input of size %d written to %s\n", day, *size, *out)
+	}
+	if len(overrides) > 0 {
+		fmt.Fprintf(os.Stderr, "⚙️ Solve with %s\n", paramFlags(overrides))
+	}
+}
+
+// GenerateInput returns a synthetic input for a day, and the parameters it must be solved with.
+// Days without a generator are fatal.
+func GenerateInput(day int, Solver solution.Solution, size int, seed uint64) (string, map[string]string) {
+	generator, ok := Solver.(solution.Generator)
+	if !ok {
+		log.Fatalf("Day %d has no input generator.\n", day)
+	}
+	input, overrides := generator.Generate(rand.New(rand.NewPCG(seed, uint64(day))), size)
+	return solution.NormalizeInput(Solver, input), overrides
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// paramFlags returns the parameters as -param flags, in order of name.
+func paramFlags(overrides map[string]string) string {
+	flags := make([]string, 0, len(overrides))
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		flags = append(flags, fmt.Sprintf("-param %s=%s", name, overrides[name]))
+	}
+	return strings.Join(flags, " ")
+}
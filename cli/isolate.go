@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/isolate"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/trace"
+)
+
+/* ------------------------------ Isolated Runs ----------------------------- */
+
+// The icon shown for each way an isolated day can end.
+var statusIcons = map[isolate.Status]string{
+	isolate.OK:          "✅",
+	isolate.Crashed:     "💥",
+	isolate.OutOfMemory: "💾",
+	isolate.CPULimit:    "🔥",
+	isolate.TimedOut:    "⌛",
+}
+
+// RunIsolated solves each day in its own child process and prints the answers, so a day which
+// panics, exits, runs out of memory or never finishes only fails itself. A summary of every day is
+// printed at the end, and the process exits with a failure status if any day failed.
+func RunIsolated(days []int, options *config.Options) {
+	outcomes := make([]isolate.Outcome, len(days))
+	for i, day := range days {
+		outcomes[i] = RunIsolatedSolution(day, options)
+		if len(days) > 1 && options.Format == config.TEXT {
+			fmt.Fprintln(Stdout)
+		}
+	}
+
+	failed := false
+	for _, outcome := range outcomes {
+		failed = failed || outcome.Status != isolate.OK
+	}
+	if len(days) > 1 && options.Format == config.TEXT {
+		PrintIsolatedSummary(days, outcomes)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// RunIsolatedSolution solves a day in a child process, with the options' resource limits and timeout,
+// and prints the answers or the reason the child failed.
+func RunIsolatedSolution(day int, options *config.Options) isolate.Outcome {
+	Solver := solution.Solutions[dayPath(day)]
+	// Check the parameters here, so a mistake is reported once rather than as a crash
+	DayContext(context.Background(), day, Solver.Solution, options)
+	start := time.Now()
+	request := isolate.Request{
+		Day:     day,
+		Input:   ReadInput(day, Solver, options),
+		Params:  options.ParamsFor(day),
+		Workers: options.Workers,
+		Limits: isolate.Limits{
+			CPU:    time.Duration(options.CPULimit),
+			Memory: int64(options.MemoryLimit) << 20,
+		},
+		Trace: options.Trace(),
+	}
+
+	if options.Format == config.JSON {
+		outcome := solveIsolated(request, options.TimeoutFor(day))
+		answer1, answer2 := outcome.Response.Answers()
+		result := NewResult(day, Solver, answer1, answer2, time.Since(start), outcome.Err)
+		result.Status = outcome.Status
+		line, _ := json.Marshal(result)
+		fmt.Fprintln(Stdout, string(line))
+		return outcome
+	}
+
+	fmt.Fprintf(Stdout, "🎄 Advent of Code [%d] - Day %v: %s %v\n", options.Year, day, Solver.Title, Solver.Icon)
+	done := make(chan struct{})
+	go indicator(done, progress.New())
+	outcome := solveIsolated(request, options.TimeoutFor(day))
+	close(done)
+
+	if outcome.Status == isolate.OK {
+		answer1, answer2 := outcome.Response.Answers()
+		PrintAnswers(answer1, answer2, nil)
+	} else {
+		clearIndicator()
+		fmt.Fprintf(Stdout, "\t%s No Solution, %s: %v\n\n", statusIcons[outcome.Status], outcome.Status, outcome.Err)
+	}
+	if options.Verbose {
+		printCaptured("stdout", outcome.Stdout)
+		printCaptured("stderr", outcome.Stderr)
+	}
+	fmt.Fprintf(Stdout, "🕒 Execution Time: %v\n", time.Since(start))
+	return outcome
+}
+
+// PrintIsolatedSummary prints how each isolated day ended, one line per day.
+func PrintIsolatedSummary(days []int, outcomes []isolate.Outcome) {
+	fmt.Fprintln(Stdout, "📋 Summary")
+	for i, day := range days {
+		Solver := solution.Solutions[dayPath(day)]
+		outcome := outcomes[i]
+		fmt.Fprintf(Stdout, "%s Day %2d  %s\t%s: %s", statusIcons[outcome.Status], day, Solver.Icon, Solver.Title, outcome.Status)
+		if outcome.Err != nil {
+			fmt.Fprintf(Stdout, " (%v)", outcome.Err)
+		}
+		fmt.Fprintln(Stdout)
+	}
+}
+
+// ServeIsolated is the entry point of a child process started by RunIsolated. It solves the day
+// in the request it is sent and writes the answers back to its parent.
+func ServeIsolated() {
+	isolate.Serve(func(request isolate.Request) isolate.Response {
+		Solver, ok := solution.Solutions[dayPath(request.Day)]
+		if !ok {
+			log.Fatalf("No solution exists for day %d.\n", request.Day)
+		}
+		if request.Workers > 0 {
+			runtime.GOMAXPROCS(request.Workers)
+		}
+		if err := trace.Setup(request.Trace); err != nil {
+			log.Fatal(err)
+		}
+		ctx, err := solution.WithParams(parallel.WithWorkers(context.Background(), request.Workers), Solver.Solution, request.Params)
+		if err != nil {
+			log.Fatalf("Day %d: %v\n", request.Day, err)
+		}
+
+		start := time.Now()
+		answer1, answer2 := solution.SolveAnswers(ctx, Solver.Solution, request.Input)
+		return isolate.NewResponse(answer1, answer2, time.Since(start))
+	})
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// solveIsolated runs the request in a child process of this executable, which is killed if it
+// takes longer than the timeout. A timeout of zero means no limit.
+func solveIsolated(request isolate.Request, timeout time.Duration) isolate.Outcome {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return isolate.Child{Path: executable, Args: []string{isolate.CHILD_COMMAND}}.Run(ctx, request)
+}
+
+// printCaptured prints the output a child process wrote to one of its streams, if any.
+func printCaptured(name, output string) {
+	if output = strings.TrimSpace(output); output == "" {
+		return
+	}
+	fmt.Fprintf(Stdout, "📄 Captured %s:\n", name)
+	for _, line := range strings.Split(output, "\n") {
+		fmt.Fprintf(Stdout, "\t%s\n", line)
+	}
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* --------------------------- Leaderboard Command -------------------------- */
+
+// The marks shown for a day on which a member has collected no, one or both stars.
+var starMarks = [3]string{"·", "☆", "★"}
+
+// Leaderboard prints the standings of a private leaderboard, read from a file or downloaded from the
+// website, or from the -url of a stand-in. Downloads are cached in the data directory, and a leaderboard
+// is downloaded at most once per refresh interval.
+func Leaderboard(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("leaderboard", summary("leaderboard"), &options)
+	flags.IntVar(&options.LeaderboardID, "id", options.LeaderboardID, "The ID of the private leaderboard, as shown in its address on the website.")
+	flags.StringVar(&options.LeaderboardURL, "url", options.LeaderboardURL, "The address of the leaderboard's JSON, instead of the website's.")
+	flags.Var(&options.LeaderboardRefresh, "refresh", "The shortest time between downloads of the leaderboard, e.g. 15m.")
+	file := flags.String("file", "", "Read the leaderboard's JSON from a file instead of downloading it.")
+	day := flags.Int("day", 0, "Also show how long each member took to complete each part of this day.")
+	parseOptions(flags, &options, argv)
+
+	leaderboard, updated := loadLeaderboard(*file, &options)
+	standings := leaderboard.Standings()
+	if options.Format == config.JSON {
+		data, _ := json.Marshal(standings)
+		fmt.Fprintln(Stdout, string(data))
+		return
+	}
+
+	year, _ := leaderboard.Year()
+	fmt.Fprintf(Stdout, "🏆 Private Leaderboard [%d], updated %v ago\n", year, time.Since(updated).Round(time.Second))
+	PrintStandings(standings)
+	if *day > 0 {
+		fmt.Fprintln(Stdout)
+		PrintDayTimes(standings, *day)
+	}
+}
+
+// PrintStandings prints a table of each member's rank, score and stars, with a column for each day headed by
+// the day's icon. Days up to the last with a solution or a star are shown.
+func PrintStandings(standings []aoc.Standing) {
+	days := selectDays("all")
+	last := days[len(days)-1]
+	width := len("Name")
+	for _, standing := range standings {
+		width = max(width, len(standing.Name))
+		for day := last + 1; day <= aoc.DAYS; day++ {
+			if standing.DayStars(day) > 0 {
+				last = day
+			}
+		}
+	}
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "%3s  %-*s %5s %5s  ", "", width, "Name", "Score", "Stars")
+	for day := 1; day <= last; day++ {
+		if Solver, ok := solution.Solutions[dayPath(day)]; ok {
+			header.WriteString(Solver.Icon + " ")
+		} else {
+			fmt.Fprintf(&header, "%2d ", day)
+		}
+	}
+	fmt.Fprintln(Stdout, strings.TrimRight(header.String(), " "))
+
+	for _, standing := range standings {
+		var row strings.Builder
+		fmt.Fprintf(&row, "%3d) %-*s %5d %5d  ", standing.Rank, width, standing.Name, standing.Score, standing.Stars)
+		for day := 1; day <= last; day++ {
+			row.WriteString(starMarks[standing.DayStars(day)] + "  ")
+		}
+		fmt.Fprintln(Stdout, strings.TrimRight(row.String(), " "))
+	}
+}
+
+// PrintDayTimes prints how long each member who has collected a star on a day took to complete each part,
+// fastest first. Members who completed both parts are listed before those who only completed the first.
+func PrintDayTimes(standings []aoc.Standing, day int) {
+	title := fmt.Sprintf("Day %d", day)
+	if Solver, ok := solution.Solutions[dayPath(day)]; ok {
+		title = fmt.Sprintf("Day %d: %s %s", day, Solver.Title, Solver.Icon)
+	}
+	fmt.Fprintf(Stdout, "⏱️ %s\n", title)
+	if day < 1 || day > aoc.DAYS {
+		return
+	}
+
+	finishers := slices.DeleteFunc(slices.Clone(standings), func(s aoc.Standing) bool { return s.DayStars(day) == 0 })
+	slices.SortStableFunc(finishers, func(a, b aoc.Standing) int {
+		timesA, timesB := a.Times[day-1], b.Times[day-1]
+		return cmp.Or(cmp.Compare(b.DayStars(day), a.DayStars(day)), cmp.Compare(timesA[1], timesB[1]), cmp.Compare(timesA[0], timesB[0]))
+	})
+
+	width := 0
+	for _, standing := range finishers {
+		width = max(width, len(standing.Name))
+	}
+	for _, standing := range finishers {
+		times := standing.Times[day-1]
+		fmt.Fprintf(Stdout, "\t%-*s  part 1: %-10s part 2: %s\n", width, standing.Name, formatTime(times[0]), formatTime(times[1]))
+	}
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// loadLeaderboard reads the leaderboard from a file, or downloads it if its cached copy is out of date, and
+// returns it with the time it was last updated. A leaderboard on the website is never downloaded more often
+// than the website asks, whatever the refresh interval.
+func loadLeaderboard(file string, options *config.Options) (*aoc.Leaderboard, time.Time) {
+	if file != "" {
+		leaderboard, err := aoc.LoadLeaderboard(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return leaderboard, info.ModTime()
+	}
+
+	// The session is only needed when the cached copy is out of date
+	session, sessionErr := aoc.LoadSession()
+	client := aoc.NewClient(session, options.DataDir)
+	client.BaseURL = options.BaseURL
+	client.Year = options.Year
+
+	address := options.LeaderboardURL
+	if address == "" {
+		if options.LeaderboardID == 0 {
+			log.Fatal("Choose a private leaderboard with -id, -url or -file.")
+		}
+		address = client.LeaderboardURL(options.LeaderboardID)
+	}
+	refresh := time.Duration(options.LeaderboardRefresh)
+	if strings.HasPrefix(address, aoc.DEFAULT_BASE_URL) && refresh < aoc.DEFAULT_LEADERBOARD_REFRESH {
+		fmt.Fprintf(os.Stderr, "⚠️ Leaderboards are downloaded at most every %v, as the website asks.\n", aoc.DEFAULT_LEADERBOARD_REFRESH)
+		refresh = aoc.DEFAULT_LEADERBOARD_REFRESH
+	}
+
+	leaderboard, updated, err := client.FetchLeaderboard(address, refresh)
+	if errors.Is(err, aoc.ErrNoSession) {
+		err = sessionErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return leaderboard, updated
+}
+
+// formatTime returns the time taken to complete a part to the second, or "-" if it is not complete.
+func formatTime(elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "-"
+	}
+	return elapsed.Round(time.Second).String()
+}
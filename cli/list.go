@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* ------------------------------- List Command ------------------------------- */
+
+// Listing describes a registered day, as reported by the list command and the HTTP API.
+type Listing struct {
+	Day       int    `json:"day"`
+	Icon      string `json:"icon"`
+	Title     string `json:"title"`
+	Visualize bool   `json:"visualize"`
+	Animate   bool   `json:"animate"`
+	// The names of the day's variants, other than the built-in solution.
+	Variants []string `json:"variants,omitempty"`
+}
+
+// List prints every registered day with its icon and title, noting which can be rendered or animated
+// and the names of any variants.
+func List(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("list", summary("list"), &options)
+	parseOptions(flags, &options, argv)
+
+	listings := Listings()
+	if options.Format == config.JSON {
+		data, _ := json.Marshal(listings)
+		fmt.Fprintln(Stdout, string(data))
+		return
+	}
+
+	for _, listing := range listings {
+		features := ""
+		if listing.Visualize {
+			features += " 🎨"
+		}
+		if listing.Animate {
+			features += " 🎞️"
+		}
+		if len(listing.Variants) > 0 {
+			features += " 🧩 " + strings.Join(listing.Variants, ", ")
+		}
+		fmt.Fprintf(Stdout, "Day %2d  %s\t%s%s\n", listing.Day, listing.Icon, listing.Title, features)
+	}
+}
+
+// Listings returns a description of every registered day, in order of day.
+func Listings() []Listing {
+	listings := make([]Listing, 0, len(solution.Solutions))
+	for _, day := range selectDays("all") {
+		Solver := solution.Solutions[dayPath(day)]
+		_, visualize := Solver.Solution.(solution.Visualizer)
+		_, animate := Solver.Solution.(solution.Animator)
+		var variants []string
+		if names := solution.VariantNames(dayPath(day)); len(names) > 1 {
+			variants = names[1:]
+		}
+		listings = append(listings, Listing{day, Solver.Icon, Solver.Title, visualize, animate, variants})
+	}
+	return listings
+}
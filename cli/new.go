@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/scaffold"
+)
+
+/* -------------------------------- New Command ------------------------------- */
+
+// New creates the package for a new day from the solution template, using the title and example
+// input from the puzzle's description page. The page is read from a local file if one is given.
+func New(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("new", summary("new"), &options)
+	day := flags.Int("day", 0, "The day of the Advent of Code challenge to create a package for.")
+	page := flags.String("page", "", "A saved copy of the puzzle description page. Downloaded if not given.")
+	icon := flags.String("icon", scaffold.DEFAULT_ICON, "The icon shown when running the day's solution.")
+	parseOptions(flags, &options, argv)
+
+	var puzzle aoc.PuzzlePage
+	var err error
+	if *page != "" {
+		puzzle, err = aoc.LoadPuzzlePage(*page)
+	} else {
+		puzzle, err = newClient(&options).FetchPuzzlePage(*day)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *day != 0 && puzzle.Day != *day {
+		log.Fatalf("The puzzle page is for day %d, not day %d.\n", puzzle.Day, *day)
+	}
+
+	dir, err := scaffold.New(".", puzzle, *icon)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(Stdout, "🛠️ Created %s for Day %d: %s\n", dir, puzzle.Day, puzzle.Title)
+}
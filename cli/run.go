@@ -0,0 +1,370 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/isolate"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/memo"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+/* -------------------------------- Run Command ------------------------------- */
+
+// Run solves one or all days and prints the answers.
+func Run(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("run", summary("run"), &options)
+	options.BindRun(flags)
+	parseOptions(flags, &options, argv)
+
+	days := selectOptionDays(&options)
+	if options.Isolate {
+		RunIsolated(days, &options)
+		return
+	}
+	for i, day := range days {
+		RunSolution(day, &options)
+		if len(days) > 1 && i < len(days)-1 && options.Format == config.TEXT {
+			fmt.Fprintln(Stdout)
+		}
+	}
+}
+
+// RunSolution solves a day and prints the answers, then renders or animates it if requested.
+func RunSolution(day int, options *config.Options) {
+	Solver := solverFor(day, options.Variant)
+	registry := memo.NewRegistry()
+	ctx := memo.WithRegistry(DayContext(context.Background(), day, Solver.Solution, options), registry)
+	start := time.Now()
+	input := ReadInput(day, Solver, options)
+
+	// Run the solution
+	if options.Format == config.JSON {
+		answer1, answer2, err := Solve(ctx, Solver.Solution, input, options.TimeoutFor(day))
+		result := NewResult(day, Solver, answer1, answer2, time.Since(start), err)
+		if isVariant(options.Variant) {
+			result.Variant = options.Variant
+		}
+		PrintJSON(result)
+	} else {
+		fmt.Fprintf(Stdout, "🎄 Advent of Code [%d] - Day %v: %s %v\n", options.Year, day, Solver.Title, Solver.Icon)
+		if isVariant(options.Variant) {
+			fmt.Fprintf(Stdout, "🧩 Variant: %s\n", options.Variant)
+		}
+		if parameterized, ok := Solver.Solution.(solution.Parameterized); ok && options.Verbose {
+			fmt.Fprintf(Stdout, "⚙️ Params: %v\n", params.FromContext(ctx, parameterized.Params()))
+		}
+		done := make(chan struct{})
+		reporter := progress.New()
+		go indicator(done, reporter)
+		answer1, answer2, err := Solve(progress.WithReporter(ctx, reporter), Solver.Solution, input, options.TimeoutFor(day))
+		close(done)
+		PrintAnswers(answer1, answer2, err)
+		fmt.Fprintf(Stdout, "🕒 Execution Time: %v\n", time.Since(start))
+	}
+
+	if options.Verbose {
+		for _, name := range registry.Names() {
+			fmt.Fprintf(Stdout, "📦 Cache %s: %v\n", name, registry.Stats(name))
+		}
+	}
+
+	if options.Render != "" {
+		Render(ctx, Solver.Solution, input, dayPath(day), options)
+	}
+	if options.Visualize != "" {
+		Animate(ctx, Solver.Solution, input, options)
+	}
+}
+
+// ReadInput reads and normalizes a day's puzzle input, downloading it first if requested and missing.
+func ReadInput(day int, Solver solution.Solver, options *config.Options) string {
+	path := options.InputPath(day)
+	if _, err := os.Stat(path); os.IsNotExist(err) && options.Fetch {
+		FetchInput(day, options)
+	}
+
+	input := util.ReadFile(path)
+	return solution.NormalizeInput(Solver.Solution, input)
+}
+
+// DayContext returns a context carrying the puzzle parameters for a day, from the config file and -param flags,
+// and the number of parallel workers the day may use. Parameters which the day does not declare, or which
+// are not valid, are fatal.
+func DayContext(ctx context.Context, day int, Solver solution.Solution, options *config.Options) context.Context {
+	ctx, err := solution.WithParams(parallel.WithWorkers(ctx, options.Workers), Solver, options.ParamsFor(day))
+	if err != nil {
+		log.Fatalf("Day %d: %v\n", day, err)
+	}
+	return ctx
+}
+
+// ErrTimedOut is the error of a puzzle which took longer than its timeout to solve.
+var ErrTimedOut = errors.New("timed out")
+
+// ErrPanicked is the error of a puzzle whose solver panicked.
+var ErrPanicked = errors.New("panic")
+
+// Solve the puzzle, giving up with an error if it takes longer than the timeout, or the context is cancelled.
+// A timeout of zero means no limit. The context is passed on to solvers which accept one, and is
+// cancelled when the timeout expires. The error wraps ErrTimedOut if the timeout expired, or the
+// context's error if it was cancelled first.
+//
+// A solver which does not stop when its context is cancelled is abandoned: it keeps running in the
+// background until it finishes, and its answers are discarded. A solver which panics fails both parts.
+func Solve(ctx context.Context, Solver solution.Solution, input string, timeout time.Duration) (answer.Answer, answer.Answer, error) {
+	return solveThen(ctx, Solver, input, timeout, nil)
+}
+
+// solveThen solves the puzzle as Solve does, calling done, if it is not nil, once the solver has returned.
+// That may be after solveThen itself has returned, if the solver was abandoned.
+func solveThen(ctx context.Context, Solver solution.Solution, input string, timeout time.Duration, done func()) (answer.Answer, answer.Answer, error) {
+	solved, err := runThen(ctx, timeout, done, func(ctx context.Context) ([2]answer.Answer, error) {
+		answer1, answer2 := solution.SolveAnswers(ctx, Solver, input)
+		return [2]answer.Answer{answer1, answer2}, nil
+	})
+	if errors.Is(err, ErrPanicked) {
+		return answer.FromError(err), answer.FromError(err), nil
+	}
+	return solved[0], solved[1], err
+}
+
+// runThen runs work in its own goroutine, with the timeout and cancellation of Solve, calling done, if it
+// is not nil, once work has returned. A panic in work is recovered, and returned as an error wrapping ErrPanicked.
+func runThen[T any](ctx context.Context, timeout time.Duration, done func(), work func(context.Context) (T, error)) (T, error) {
+	parent := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		if done != nil {
+			defer done()
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				results <- result{err: fmt.Errorf("%w: %v", ErrPanicked, r)}
+			}
+		}()
+		value, err := work(ctx)
+		results <- result{value, err}
+	}()
+
+	var zero T
+	select {
+	case r := <-results:
+		return r.value, r.err
+	case <-ctx.Done():
+		if err := parent.Err(); err != nil {
+			return zero, fmt.Errorf("cancelled: %w", err)
+		}
+		return zero, fmt.Errorf("%w after %v", ErrTimedOut, timeout)
+	}
+}
+
+// Print the answers to the terminal, replacing the "Solving" indicator.
+// Grids are printed below their label, and parts which failed are printed with their error.
+func PrintAnswers(answer1, answer2 answer.Answer, err error) {
+	clearIndicator()
+	if err != nil {
+		fmt.Fprintf(Stdout, "\t⌛ No Solution: %v\n\n", err)
+		return
+	}
+	for part, solved := range []answer.Answer{answer1, answer2} {
+		switch solved.Kind {
+		case answer.Error:
+			fmt.Fprintf(Stdout, "\t❌ Part %d: %v\n", part+1, solved.Err())
+		case answer.Grid:
+			fmt.Fprintf(Stdout, "\t✅ Part %d Solution:\n%s\n", part+1, solved)
+		default:
+			fmt.Fprintf(Stdout, "\t✅ Part %d Solution: %s\n", part+1, solved)
+		}
+	}
+	fmt.Fprintln(Stdout)
+}
+
+// Result is the outcome of solving a day, as reported in JSON output.
+// Integer answers are JSON numbers, and answers which could not be found are null.
+type Result struct {
+	Day   int           `json:"day"`
+	Title string        `json:"title"`
+	Part1 answer.Answer `json:"part1"`
+	Part2 answer.Answer `json:"part2"`
+	Time  string        `json:"time"`
+	Error string        `json:"error,omitempty"`
+	// The variant of the day's solution, when it is not the built-in one.
+	Variant string `json:"variant,omitempty"`
+	// How the day's process ended, when it was solved with -isolate.
+	Status isolate.Status `json:"status,omitempty"`
+}
+
+// NewResult returns the result of solving a day. The error is the day's, or that of the first part which failed.
+func NewResult(day int, Solver solution.Solver, answer1, answer2 answer.Answer, elapsed time.Duration, err error) Result {
+	result := Result{Day: day, Title: Solver.Title, Part1: answer1, Part2: answer2, Time: elapsed.String()}
+	for part, solved := range []answer.Answer{answer1, answer2} {
+		if err == nil && solved.Err() != nil {
+			err = fmt.Errorf("part %d: %w", part+1, solved.Err())
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Print the result of a day as a single line of JSON.
+func PrintJSON(result Result) {
+	line, _ := json.Marshal(result)
+	fmt.Fprintln(Stdout, string(line))
+}
+
+// Returns a client for the Advent of Code website, configured by the options.
+func newClient(options *config.Options) *aoc.Client {
+	session, err := aoc.LoadSession()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := aoc.NewClient(session, options.DataDir)
+	client.BaseURL = options.BaseURL
+	client.Year = options.Year
+	return client
+}
+
+// Download the input for a day into the data directory.
+func FetchInput(day int, options *config.Options) {
+	path, err := newClient(options).FetchInput(day)
+	if err != nil && !errors.Is(err, aoc.ErrInputExists) {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(Stdout, "📥 Puzzle input saved to %s\n", path)
+}
+
+// Render a visualization of the puzzle, if the solution supports it.
+func Render(ctx context.Context, Solver solution.Solution, input, path string, options *config.Options) {
+	visualizer, ok := Solver.(solution.Visualizer)
+	if !ok {
+		fmt.Fprintln(Stdout, "🎨 No visualization available for this day.")
+		return
+	}
+	grid, err := visualizer.Visualize(ctx, input)
+	if err != nil {
+		fmt.Fprintf(Stdout, "🎨 No visualization: %v\n", err)
+		return
+	}
+
+	switch options.Render {
+	case "ascii":
+		fmt.Fprint(Stdout, grid.ASCII())
+	case "ansi":
+		fmt.Fprint(Stdout, grid.ANSI())
+	case "png":
+		out := options.RenderOut
+		if out == "" {
+			out = path + ".png"
+		}
+		file, err := os.Create(out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		if err := grid.PNG(file, options.CellSize); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(Stdout, "🎨 Visualization written to %s\n", out)
+	default:
+		log.Fatalf("Invalid render format %s. Expected ascii, ansi or png.\n", options.Render)
+	}
+}
+
+// Record an animation of the puzzle's simulation and write it as a GIF, if the solution supports it.
+func Animate(ctx context.Context, Solver solution.Solution, input string, options *config.Options) {
+	animator, ok := Solver.(solution.Animator)
+	if !ok {
+		fmt.Fprintln(Stdout, "🎞️ No animation available for this day.")
+		return
+	}
+
+	recorder := render.NewRecorder(options.FrameEvery, options.CellSize)
+	recorder.MaxFrames = options.MaxFrames
+	if err := animator.Animate(ctx, input, recorder); err != nil {
+		fmt.Fprintf(Stdout, "🎞️ No animation: %v\n", err)
+		return
+	}
+	if recorder.Frames() == 0 {
+		fmt.Fprintf(Stdout, "🎞️ No animation: %v\n", render.ErrNoFrames)
+		return
+	}
+
+	file, err := os.Create(options.Visualize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := recorder.EncodeGIF(file); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(Stdout, "🎞️ Animation of %d frames written to %s\n", recorder.Frames(), options.Visualize)
+}
+
+// Clear the "Solving" indicator, and show the cursor again.
+func clearIndicator() {
+	// Clear the "Solving" indicator
+	fmt.Fprint(Stdout, "\033[2K")
+	// show the cursor
+	fmt.Fprint(Stdout, "\x1B[?25h")
+	fmt.Fprintln(Stdout)
+}
+
+// Show that the solution is running, until done is closed.
+// Solvers which report their progress get a progress bar, and the rest a spinner.
+func indicator(done chan struct{}, reporter *progress.Reporter) {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	fmt.Fprint(Stdout, "\t⏳ Solving: ")
+	// Save the cursor position
+	fmt.Fprint(Stdout, "\x1B7")
+	// Hide the cursor
+	fmt.Fprint(Stdout, "\x1B[?25l")
+
+	animation := []string{"|", "/", "-", "\\"}
+
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Restore the cursor position
+			fmt.Fprint(Stdout, "\x1B8")
+			// Save the cursor position
+			fmt.Fprint(Stdout, "\x1B7")
+			if snapshot := reporter.Snapshot(); snapshot.Known() {
+				// Clear the rest of the line, as the phase name may have got shorter
+				fmt.Fprint(Stdout, snapshot.String()+"\x1B[K")
+				continue
+			}
+			fmt.Fprint(Stdout, animation[0])
+			animation = append(animation[1:], animation[0])
+		case <-done:
+			return
+		}
+	}
+}
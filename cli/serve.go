@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+/* ------------------------------ Serve Command ------------------------------- */
+
+// Serve starts an HTTP API for listing, solving and rendering puzzles.
+func Serve(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("serve", summary("serve"), &options)
+	addr := flags.String("addr", "localhost:8080", "The address to listen on.")
+	parseOptions(flags, &options, argv)
+
+	fmt.Fprintf(Stdout, "🌐 Serving on http://%s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, Handler(&options)))
+}
+
+// Handler returns the HTTP API:
+//
+//	GET  /days              lists every registered day
+//	POST /days/{day}        solves the puzzle input in the request body
+//	POST /days/{day}/render renders the puzzle input in the request body as a PNG image
+//
+// Puzzle parameters may be set in the query string of a POST, e.g. /days/18?size=7&bytes=12.
+// At most one puzzle per CPU is solved at once, and further requests wait for a solver to finish.
+func Handler(options *config.Options) http.Handler {
+	mux := http.NewServeMux()
+	// A slot is held until its solver returns, even if the request timed out or was cancelled, so
+	// solvers which ignore cancellation cannot pile up behind requests which have given up on them
+	slots := make(chan struct{}, runtime.NumCPU())
+
+	mux.HandleFunc("GET /days", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, Listings())
+	})
+
+	mux.HandleFunc("POST /days/{day}", func(w http.ResponseWriter, r *http.Request) {
+		day, Solver, input, ok := readRequest(w, r)
+		if !ok {
+			return
+		}
+		ctx, ok := requestContext(w, r, day, Solver, options)
+		if !ok {
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			http.Error(w, "cancelled while waiting for a solver", http.StatusServiceUnavailable)
+			return
+		}
+		start := time.Now()
+		answer1, answer2, err := solveThen(ctx, Solver.Solution, input, options.TimeoutFor(day), func() { <-slots })
+		if err != nil && !errors.Is(err, ErrTimedOut) {
+			// The client has gone, so there is no one to respond to
+			return
+		}
+
+		result := NewResult(day, Solver, answer1, answer2, time.Since(start), err)
+		if err != nil {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+		writeJSON(w, result)
+	})
+
+	mux.HandleFunc("POST /days/{day}/render", func(w http.ResponseWriter, r *http.Request) {
+		day, Solver, input, ok := readRequest(w, r)
+		if !ok {
+			return
+		}
+		ctx, ok := requestContext(w, r, day, Solver, options)
+		if !ok {
+			return
+		}
+		visualizer, ok := Solver.Solution.(solution.Visualizer)
+		if !ok {
+			http.Error(w, "no visualization available for this day", http.StatusNotFound)
+			return
+		}
+		// Visualizing may solve the puzzle, so it is limited as solving is
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			http.Error(w, "cancelled while waiting for a solver", http.StatusServiceUnavailable)
+			return
+		}
+		grid, err := runThen(ctx, options.TimeoutFor(day), func() { <-slots }, func(ctx context.Context) (*render.Grid, error) {
+			return visualizer.Visualize(ctx, input)
+		})
+		switch {
+		case errors.Is(err, ErrTimedOut):
+			http.Error(w, err.Error(), http.StatusGatewayTimeout)
+			return
+		case errors.Is(err, ErrPanicked):
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		case errors.Is(err, context.Canceled):
+			// The client has gone, so there is no one to respond to
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		grid.PNG(w, options.CellSize)
+	})
+
+	return mux
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// readRequest returns the day and puzzle input of a request, writing an error response if either is invalid.
+func readRequest(w http.ResponseWriter, r *http.Request) (int, solution.Solver, string, bool) {
+	day, err := strconv.Atoi(r.PathValue("day"))
+	Solver, ok := solution.Solutions[dayPath(day)]
+	if err != nil || !ok {
+		http.Error(w, fmt.Sprintf("no solution exists for day %s", r.PathValue("day")), http.StatusNotFound)
+		return 0, Solver, "", false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, Solver, "", false
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		http.Error(w, "the request body must contain the puzzle input", http.StatusBadRequest)
+		return 0, Solver, "", false
+	}
+	return day, Solver, solution.NormalizeInput(Solver.Solution, string(body)), true
+}
+
+// requestContext returns the request's context carrying the day's puzzle parameters, which may be
+// overridden by the query string, e.g. ?size=7&bytes=12. Invalid parameters are a bad request.
+func requestContext(w http.ResponseWriter, r *http.Request, day int, Solver solution.Solver, options *config.Options) (context.Context, bool) {
+	overrides := options.ParamsFor(day)
+	for name, values := range r.URL.Query() {
+		overrides[name] = values[len(values)-1]
+	}
+
+	ctx, err := solution.WithParams(parallel.WithWorkers(r.Context(), options.Workers), Solver.Solution, overrides)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return ctx, true
+}
+
+// writeJSON writes a value as the JSON body of a response.
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
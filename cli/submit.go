@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+/* ------------------------------ Submit Command ------------------------------ */
+
+// Submit solves a day's puzzle and submits the answer for one part to the website.
+// Answers which the answer store already knows to be wrong are never submitted.
+func Submit(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("submit", summary("submit"), &options)
+	day := flags.Int("day", 0, "The day of the Advent of Code challenge to submit an answer for.")
+	part := flags.Int("part", 1, "The part of the puzzle to submit an answer for (1 or 2).")
+	parseOptions(flags, &options, argv)
+
+	Solver, ok := solution.Solutions[dayPath(*day)]
+	if !ok {
+		log.Fatalf("Invalid day specified. No solution exists for day %d.\n", *day)
+	}
+	if *part != 1 && *part != 2 {
+		log.Fatalf("Invalid part specified. Expected 1 or 2, got %d.\n", *part)
+	}
+
+	// Solve the puzzle to get the answer
+	ctx := DayContext(context.Background(), *day, Solver.Solution, &options)
+	input := ReadInput(*day, Solver, &options)
+	answer1, answer2 := solution.SolveAnswers(ctx, Solver.Solution, input)
+	solved := answer1
+	if *part == 2 {
+		solved = answer2
+	}
+	if !solved.Valid() {
+		log.Fatalf("Not submitting: part %d has no answer: %v\n", *part, solved.Err())
+	}
+	answer := solved.String()
+	fmt.Fprintf(Stdout, "🎄 Advent of Code [%d] - Day %d: %s %v\n", options.Year, *day, Solver.Title, Solver.Icon)
+	fmt.Fprintf(Stdout, "\t📮 Submitting Part %d Solution: %s\n", *part, answer)
+
+	submissions, err := aoc.LoadSubmissionLog(options.AnswerStore)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := submissions.Check(*day, *part, answer); err != nil {
+		log.Fatalf("Not submitting: %v\n", err)
+	}
+
+	submission, err := newClient(&options).SubmitAnswer(*day, *part, answer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := submissions.Add(submission); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(Stdout, "\t📬 Result: %s\n\t%s\n", submission.Result, submission.Message)
+}
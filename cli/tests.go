@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"log"
+	"os"
+	"os/exec"
+
+	"shaneholland.dev/aoc-2024/config"
+)
+
+/* ------------------------------- Test Command ------------------------------- */
+
+// RunTests runs the unit tests for one or all days, using the go tool.
+func RunTests(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("test", summary("test"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to test.")
+	verbose := flags.Bool("v", false, "Print the name of each test as it runs.")
+	parseOptions(flags, &options, argv)
+
+	args := []string{"test"}
+	if *verbose {
+		args = append(args, "-v")
+	}
+	for _, day := range selectDays(options.Day) {
+		args = append(args, "./solution/"+dayPath(day)+"/")
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			os.Exit(exit.ExitCode())
+		}
+		log.Fatal(err)
+	}
+}
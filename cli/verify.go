@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/config"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/answer"
+)
+
+/* ------------------------------ Verify Command ------------------------------ */
+
+// Verify solves one or all days and checks the answers against the known correct answers in the
+// answer store. Every variant of each day's solution is checked, unless a single -variant is chosen.
+// The process exits with a failure status if any answer differs.
+func Verify(argv []string) {
+	options := config.Defaults()
+	flags := newFlagSet("verify", summary("verify"), &options)
+	flags.StringVar(&options.Day, "day", options.Day, "The day of the Advent of Code challenge to verify.")
+	flags.StringVar(&options.Variant, "variant", options.Variant, "The variant of each day's solution to verify. Defaults to every variant.")
+	parseOptions(flags, &options, argv)
+
+	submissions, err := aoc.LoadSubmissionLog(options.AnswerStore)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	failed := false
+	for _, day := range selectOptionDays(&options) {
+		variants := solution.VariantNames(dayPath(day))
+		if options.Variant != "" {
+			variants = []string{options.Variant}
+		}
+
+		for _, variant := range variants {
+			Solver := solverFor(day, variant)
+			ctx := DayContext(context.Background(), day, Solver.Solution, &options)
+			input := ReadInput(day, Solver, &options)
+			answer1, answer2, err := Solve(ctx, Solver.Solution, input, options.TimeoutFor(day))
+
+			if err != nil {
+				fmt.Fprintf(Stdout, "⌛ %s: %v\n", dayLabel(day, variant), err)
+				failed = true
+				continue
+			}
+			for part, solved := range []answer.Answer{answer1, answer2} {
+				if !VerifyAnswer(submissions, day, part+1, variant, solved) {
+					failed = true
+				}
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// VerifyAnswer prints whether an answer matches the known correct answer, returning false if it does not.
+// Answers are compared in their canonical form, so "007" matches 7. An answer with nothing to compare
+// against is reported, but is not a failure, unless the answer could not be found at all.
+// The answer is labelled with the variant of the solution which gave it, unless it is the built-in one.
+func VerifyAnswer(submissions *aoc.SubmissionLog, day, part int, variant string, solved answer.Answer) bool {
+	known, ok := submissions.Answer(day, part)
+	expected := answer.Parse(known)
+	label := dayLabel(day, variant)
+	switch {
+	case solved.Err() != nil:
+		fmt.Fprintf(Stdout, "❌ %s Part %d: %v\n", label, part, solved.Err())
+		return false
+	case !ok:
+		fmt.Fprintf(Stdout, "❔ %s Part %d: %s (no known answer)\n", label, part, solved)
+	case expected.Equal(solved):
+		fmt.Fprintf(Stdout, "✅ %s Part %d: %s\n", label, part, solved)
+	default:
+		fmt.Fprintf(Stdout, "❌ %s Part %d: got %s, expected %s\n", label, part, solved, expected)
+		return false
+	}
+	return true
+}
+
+// dayLabel returns "Day 5", followed by the name of the variant in brackets if it is not the built-in one.
+func dayLabel(day int, variant string) string {
+	if isVariant(variant) {
+		return fmt.Sprintf("Day %d [%s]", day, variant)
+	}
+	return fmt.Sprintf("Day %d", day)
+}
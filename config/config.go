@@ -0,0 +1,400 @@
+// Package config holds the options which control the runner.
+// Options are read from a project config file, then environment variables, then command line flags,
+// with each source overriding the ones before it.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/aoc"
+	"shaneholland.dev/aoc-2024/util/trace"
+)
+
+// The config file read when no other is given.
+const DEFAULT_CONFIG_FILE = ".aoc.json"
+
+// The environment variable prefix for options, e.g. AOC_DATA_DIR.
+const ENV_PREFIX = "AOC_"
+
+// Output formats for solution results.
+const (
+	TEXT = "text"
+	JSON = "json"
+)
+
+/* --------------------------- Option Definitions --------------------------- */
+
+// Options control how the runner finds, solves and reports on puzzles.
+type Options struct {
+	// The config file the options were read from. Not stored in the file itself.
+	Config string `json:"-"`
+	// The day to run, or "all". Only set from the command line.
+	Day string `json:"-"`
+	// The variant of each day's solution to run, such as a teammate's. Only set from the command line.
+	Variant string `json:"-"`
+
+	// Directory holding puzzle inputs and other downloaded data.
+	DataDir string `json:"data_dir"`
+	// The year of Advent of Code being solved.
+	Year int `json:"year"`
+	// The Advent of Code website to fetch from and submit to.
+	BaseURL string `json:"base_url"`
+	// Format of solution results, "text" or "json".
+	Format string `json:"format"`
+	// The longest a day may take to solve. Zero means no limit.
+	Timeout Duration `json:"timeout"`
+	// The number of CPUs, and parallel workers within a day, solvers may use. Zero means all of them.
+	Workers int `json:"workers"`
+	// File in which submitted answers are recorded.
+	AnswerStore string `json:"answer_store"`
+	// Print additional details about each run, such as cache statistics.
+	Verbose bool `json:"verbose"`
+	// Download missing puzzle inputs.
+	Fetch bool `json:"fetch"`
+
+	// The private leaderboard shown by the leaderboard command, by its ID on the website or the address of its JSON.
+	LeaderboardID  int    `json:"leaderboard_id"`
+	LeaderboardURL string `json:"leaderboard_url"`
+	// The shortest time between downloads of the leaderboard.
+	LeaderboardRefresh Duration `json:"leaderboard_refresh"`
+
+	// Solve each day in its own process, so a crash only fails that day.
+	Isolate bool `json:"isolate"`
+	// The CPU time an isolated day may use. Zero means no limit.
+	CPULimit Duration `json:"cpu_limit"`
+	// The memory an isolated day may use, in MiB. Zero means no limit.
+	MemoryLimit int `json:"memory_limit_mb"`
+
+	// Tracing of what solvers are doing. See the trace package.
+	LogLevel  string `json:"log_level"`
+	LogDay    string `json:"log_day"`
+	LogFile   string `json:"log_file"`
+	LogFormat string `json:"log_format"`
+
+	// Rendering and animation of solved puzzles.
+	Render     string `json:"render"`
+	RenderOut  string `json:"render_out"`
+	Visualize  string `json:"visualize"`
+	FrameEvery int    `json:"frame_every"`
+	CellSize   int    `json:"cell_size"`
+	MaxFrames  int    `json:"max_frames"`
+
+	// Puzzle parameters given on the command line, which override those in Days.
+	Params map[string]string `json:"-"`
+
+	// Overrides for individual days, keyed by day number.
+	Days map[string]DayOptions `json:"days"`
+}
+
+// DayOptions override the runner's options for a single day.
+type DayOptions struct {
+	// Path of the day's puzzle input, instead of the file in the data directory.
+	Input string `json:"input"`
+	// The longest the day may take to solve, instead of the global timeout.
+	Timeout Duration `json:"timeout"`
+	// Puzzle parameters, such as the bounds of a grid, e.g. {"bounds": "101x103"}.
+	Params map[string]string `json:"params"`
+	// Other solutions to the day, such as a teammate's, keyed by the name of the variant.
+	Variants map[string]VariantOptions `json:"variants"`
+}
+
+// VariantOptions describe where an external solution to a day is loaded from. Exactly one of the
+// command and the plugin must be given. See the external package.
+type VariantOptions struct {
+	// A program run for each puzzle, and its arguments, e.g. ["python3", "day17.py"].
+	Command []string `json:"command"`
+	// The path of a Go plugin exporting a Solution (Linux only).
+	Plugin string `json:"plugin"`
+}
+
+// Defaults returns the options used when nothing else is configured.
+func Defaults() Options {
+	return Options{
+		Config:      DEFAULT_CONFIG_FILE,
+		Day:         "all",
+		DataDir:     "./data",
+		Year:        aoc.DEFAULT_YEAR,
+		BaseURL:     aoc.DEFAULT_BASE_URL,
+		Format:      TEXT,
+		AnswerStore: "./data/submissions.json",
+		FrameEvery:  1,
+		CellSize:    4,
+		MaxFrames:   1000,
+		LogLevel:    "off",
+		LogDay:      "all",
+		LogFormat:   trace.TEXT,
+		Params:      make(map[string]string),
+		Days:        make(map[string]DayOptions),
+
+		LeaderboardRefresh: Duration(aoc.DEFAULT_LEADERBOARD_REFRESH),
+	}
+}
+
+/* ----------------------------- Loading Options ---------------------------- */
+
+// Bind registers flags for the options shared by every command.
+func (o *Options) Bind(fs *flag.FlagSet) {
+	fs.StringVar(&o.Config, "config", o.Config, "The project config file to read options from.")
+	fs.StringVar(&o.DataDir, "data-dir", o.DataDir, "The directory holding puzzle inputs.")
+	fs.IntVar(&o.Year, "year", o.Year, "The year of Advent of Code being solved.")
+	fs.StringVar(&o.BaseURL, "base-url", o.BaseURL, "The Advent of Code website to download inputs from and submit answers to.")
+	fs.StringVar(&o.Format, "format", o.Format, "The format of solution results (text or json).")
+	fs.Var(&o.Timeout, "timeout", "The longest a day may take to solve, e.g. 30s. Use 0 for no limit.")
+	fs.IntVar(&o.Workers, "workers", o.Workers, "The number of CPUs, and parallel workers within a day, solvers may use. Use 0 for all of them.")
+	fs.StringVar(&o.AnswerStore, "answer-store", o.AnswerStore, "The file in which submitted answers are recorded.")
+	fs.BoolVar(&o.Verbose, "verbose", o.Verbose, "Print additional details about each run, such as cache statistics.")
+	fs.Var(paramFlag(o.Params), "param", "Set a puzzle parameter as name=value, e.g. bounds=11x7. May be repeated.")
+}
+
+// BindRun registers flags for the options used when running solutions.
+func (o *Options) BindRun(fs *flag.FlagSet) {
+	fs.StringVar(&o.Day, "day", o.Day, "The day of the Advent of Code challenge to run.")
+	fs.StringVar(&o.Variant, "variant", o.Variant, "The variant of each day's solution to run, as named in the config file's variants.")
+	fs.BoolVar(&o.Fetch, "fetch", o.Fetch, "Download the puzzle input if it is missing, using the session token in AOC_SESSION or .aoc-session.")
+	fs.StringVar(&o.Render, "render", o.Render, "Render a visualization of the puzzle after solving it (ascii, ansi or png).")
+	fs.StringVar(&o.RenderOut, "render-out", o.RenderOut, "The file to write png renderings to. Defaults to day-{nn}.png.")
+	fs.StringVar(&o.Visualize, "visualize", o.Visualize, "Write an animated GIF of the simulation to the given file, for days which support it.")
+	fs.IntVar(&o.FrameEvery, "frame-every", o.FrameEvery, "Keep one of every n simulation steps as a frame of the animation.")
+	fs.IntVar(&o.CellSize, "cell-size", o.CellSize, "The size of each grid cell in rendered images, in pixels.")
+	fs.IntVar(&o.MaxFrames, "max-frames", o.MaxFrames, "The maximum number of frames in an animation. Use 0 for no limit.")
+	fs.BoolVar(&o.Isolate, "isolate", o.Isolate, "Solve each day in its own process, reporting crashes and exhausted limits as failures.")
+	fs.Var(&o.CPULimit, "cpu-limit", "The CPU time each isolated day may use, e.g. 30s (Linux only). Use 0 for no limit.")
+	fs.IntVar(&o.MemoryLimit, "memory-limit", o.MemoryLimit, "The memory each isolated day may use, in MiB (Linux only). Use 0 for no limit.")
+	fs.StringVar(&o.LogLevel, "log-level", o.LogLevel, "Trace what solvers are doing at this level or above (off, debug, info, warn or error).")
+	fs.StringVar(&o.LogDay, "log-day", o.LogDay, "The comma separated days to trace, e.g. 5,17, or all.")
+	fs.StringVar(&o.LogFile, "log-file", o.LogFile, "The file traces are appended to. Defaults to stderr.")
+	fs.StringVar(&o.LogFormat, "log-format", o.LogFormat, "The format of traces (text or json).")
+}
+
+// Parse fills in the options from the config file, the environment and then the command line.
+// The flag set must already have the options' flags bound to it.
+func (o *Options) Parse(fs *flag.FlagSet, argv []string, getenv func(string) string) error {
+	// The config file must be read before the flags, which take precedence over it
+	path, explicit := configPath(argv, getenv)
+	if path != "" {
+		o.Config = path
+	}
+	if err := o.LoadFile(o.Config); err != nil && (explicit || !os.IsNotExist(err)) {
+		return err
+	}
+	if err := o.LoadEnv(getenv); err != nil {
+		return err
+	}
+	if err := fs.Parse(argv); err != nil {
+		return err
+	}
+	return o.Validate()
+}
+
+// LoadFile reads options from a JSON config file. Options missing from the file are left unchanged.
+func (o *Options) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, o); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadEnv reads options from AOC_ prefixed environment variables, such as AOC_DATA_DIR.
+func (o *Options) LoadEnv(getenv func(string) string) error {
+	texts := map[string]*string{
+		"DATA_DIR":     &o.DataDir,
+		"BASE_URL":     &o.BaseURL,
+		"FORMAT":       &o.Format,
+		"ANSWER_STORE": &o.AnswerStore,
+	}
+	for name, field := range texts {
+		if value := getenv(ENV_PREFIX + name); value != "" {
+			*field = value
+		}
+	}
+
+	ints := map[string]*int{
+		"YEAR":    &o.Year,
+		"WORKERS": &o.Workers,
+	}
+	for name, field := range ints {
+		if value := getenv(ENV_PREFIX + name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%s%s: %w", ENV_PREFIX, name, err)
+			}
+			*field = n
+		}
+	}
+
+	if value := getenv(ENV_PREFIX + "TIMEOUT"); value != "" {
+		if err := o.Timeout.Set(value); err != nil {
+			return fmt.Errorf("%sTIMEOUT: %w", ENV_PREFIX, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if any option has an invalid value.
+func (o *Options) Validate() error {
+	if o.Format != TEXT && o.Format != JSON {
+		return fmt.Errorf("invalid format %q, expected %s or %s", o.Format, TEXT, JSON)
+	}
+	if o.Workers < 0 {
+		return errors.New("workers must not be negative")
+	}
+	if o.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if o.LeaderboardRefresh < 0 {
+		return errors.New("leaderboard refresh interval must not be negative")
+	}
+	if o.CPULimit < 0 || o.MemoryLimit < 0 {
+		return errors.New("resource limits must not be negative")
+	}
+	if err := o.Trace().Validate(); err != nil {
+		return err
+	}
+	if o.Isolate && (o.Render != "" || o.Visualize != "") {
+		return errors.New("isolated days cannot be rendered or visualized")
+	}
+	if o.Isolate && o.Variant != "" {
+		return errors.New("variants cannot be isolated")
+	}
+	for day, options := range o.Days {
+		for name, variant := range options.Variants {
+			if (len(variant.Command) == 0) == (variant.Plugin == "") {
+				return fmt.Errorf("variant %q of day %s needs either a command or a plugin", name, day)
+			}
+		}
+	}
+	return nil
+}
+
+// Trace returns the tracing options.
+func (o *Options) Trace() trace.Config {
+	return trace.Config{Level: o.LogLevel, Days: o.LogDay, File: o.LogFile, Format: o.LogFormat}
+}
+
+/* ----------------------------- Per-Day Options ---------------------------- */
+
+// ForDay returns the overrides configured for a day. Days may be keyed as "5" or "05".
+func (o *Options) ForDay(day int) DayOptions {
+	if options, ok := o.Days[strconv.Itoa(day)]; ok {
+		return options
+	}
+	return o.Days[fmt.Sprintf("%02d", day)]
+}
+
+// InputPath returns the path of the puzzle input for a day.
+func (o *Options) InputPath(day int) string {
+	if input := o.ForDay(day).Input; input != "" {
+		return input
+	}
+	return filepath.Join(o.DataDir, fmt.Sprintf("day-%02d.txt", day))
+}
+
+// ParamsFor returns the puzzle parameters for a day, from the config file and then the command line.
+func (o *Options) ParamsFor(day int) map[string]string {
+	merged := make(map[string]string)
+	for name, value := range o.ForDay(day).Params {
+		merged[name] = value
+	}
+	for name, value := range o.Params {
+		merged[name] = value
+	}
+	return merged
+}
+
+// TimeoutFor returns the longest a day may take to solve, or zero for no limit.
+func (o *Options) TimeoutFor(day int) time.Duration {
+	if timeout := o.ForDay(day).Timeout; timeout > 0 {
+		return time.Duration(timeout)
+	}
+	return time.Duration(o.Timeout)
+}
+
+/* --------------------------- Duration Definition -------------------------- */
+
+// Duration is a time.Duration written as a string such as "30s", in both JSON and flags.
+type Duration time.Duration
+
+// String returns the duration formatted as a string, such as "1m30s".
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Set parses the duration from a string, such as "1m30s", as required by flag.Value.
+func (d *Duration) Set(s string) error {
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads the duration from a string such as "30s".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.Set(s)
+}
+
+/* -------------------------- Param Flag Definition ------------------------- */
+
+// paramFlag collects repeated -param name=value flags into a map.
+type paramFlag map[string]string
+
+// String returns the parameters as a comma separated list.
+func (p paramFlag) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, value := range p {
+		pairs = append(pairs, name+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set adds a parameter written as name=value, as required by flag.Value.
+func (p paramFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value")
+	}
+	p[name] = value
+	return nil
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// configPath finds the config file given by the -config flag or the AOC_CONFIG environment variable.
+// explicit is true if a path was given, in which case the file must exist.
+func configPath(argv []string, getenv func(string) string) (path string, explicit bool) {
+	path = getenv(ENV_PREFIX + "CONFIG")
+	for i, arg := range argv {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			path = value
+		} else if i+1 < len(argv) {
+			path = argv[i+1]
+		}
+	}
+	return path, path != ""
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a getenv function which reads from the given map.
+func fakeEnv(env map[string]string) func(string) string {
+	return func(name string) string { return env[name] }
+}
+
+// Returns options parsed from the arguments and environment, with a flag set bound as the runner does.
+func parse(t *testing.T, argv []string, env map[string]string) (Options, error) {
+	options := Defaults()
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	options.Bind(flags)
+	options.BindRun(flags)
+	return options, options.Parse(flags, argv, fakeEnv(env))
+}
+
+// Writes a config file to a temporary directory and returns its path.
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "aoc.json")
+	assert.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestDefaults(t *testing.T) {
+	// The default config file does not exist here, which is not an error
+	options, err := parse(t, []string{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "all", options.Day)
+	assert.Equal(t, "./data", options.DataDir)
+	assert.Equal(t, TEXT, options.Format)
+	assert.Equal(t, 2024, options.Year)
+	assert.Equal(t, 15*time.Minute, time.Duration(options.LeaderboardRefresh))
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeConfig(t, `{"data_dir": "file", "year": 2023, "format": "json", "workers": 2, "timeout": "1m"}`)
+	env := map[string]string{"AOC_CONFIG": path, "AOC_YEAR": "2022", "AOC_WORKERS": "3"}
+
+	options, err := parse(t, []string{"-workers", "4", "-day", "5"}, env)
+	assert.NoError(t, err)
+	assert.Equal(t, "file", options.DataDir)
+	assert.Equal(t, JSON, options.Format)
+	assert.Equal(t, Duration(time.Minute), options.Timeout)
+	assert.Equal(t, 2022, options.Year)
+	assert.Equal(t, 4, options.Workers)
+	assert.Equal(t, "5", options.Day)
+	assert.Equal(t, path, options.Config)
+}
+
+func TestConfigFlag(t *testing.T) {
+	path := writeConfig(t, `{"format": "json"}`)
+
+	options, err := parse(t, []string{"-config=" + path}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, JSON, options.Format)
+
+	// A config file which is asked for must exist
+	_, err = parse(t, []string{"-config", filepath.Join(t.TempDir(), "missing.json")}, nil)
+	assert.Error(t, err)
+}
+
+func TestInvalidOptions(t *testing.T) {
+	_, err := parse(t, []string{"-format", "xml"}, map[string]string{"AOC_CONFIG": writeConfig(t, `{}`)})
+	assert.ErrorContains(t, err, "invalid format")
+
+	_, err = parse(t, []string{}, map[string]string{"AOC_CONFIG": writeConfig(t, `{"timeout": "soon"}`)})
+	assert.Error(t, err)
+
+	_, err = parse(t, []string{}, map[string]string{"AOC_CONFIG": writeConfig(t, `{}`), "AOC_WORKERS": "many"})
+	assert.ErrorContains(t, err, "AOC_WORKERS")
+
+	_, err = parse(t, []string{"-log-level", "verbose"}, map[string]string{"AOC_CONFIG": writeConfig(t, `{}`)})
+	assert.ErrorContains(t, err, "invalid log level")
+
+	_, err = parse(t, []string{"-log-level", "debug", "-log-day", "seventeen"}, map[string]string{"AOC_CONFIG": writeConfig(t, `{}`)})
+	assert.ErrorContains(t, err, "invalid log day")
+
+	_, err = parse(t, []string{}, map[string]string{"AOC_CONFIG": writeConfig(t, `{"leaderboard_id": 1234, "leaderboard_refresh": "-1m"}`)})
+	assert.ErrorContains(t, err, "leaderboard refresh")
+}
+
+func TestDayOverrides(t *testing.T) {
+	path := writeConfig(t, `{
+		"timeout": "10s",
+		"days": {
+			"5": {"input": "examples/day-05.txt"},
+			"14": {"timeout": "1m", "params": {"bounds": "11x7"}}
+		}
+	}`)
+
+	options, err := parse(t, []string{"-config", path, "-data-dir", "inputs"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "examples/day-05.txt", options.InputPath(5))
+	assert.Equal(t, filepath.Join("inputs", "day-06.txt"), options.InputPath(6))
+	assert.Equal(t, time.Minute, options.TimeoutFor(14))
+	assert.Equal(t, 10*time.Second, options.TimeoutFor(5))
+	assert.Equal(t, "11x7", options.ForDay(14).Params["bounds"])
+}
+
+func TestVariants(t *testing.T) {
+	path := writeConfig(t, `{"days": {"17": {"variants": {
+		"alice": {"command": ["python3", "day17.py"]},
+		"bob": {"plugin": "bob.so"}
+	}}}}`)
+	options, err := parse(t, []string{"-config", path, "-variant", "alice"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", options.Variant)
+	assert.Equal(t, []string{"python3", "day17.py"}, options.ForDay(17).Variants["alice"].Command)
+	assert.Equal(t, "bob.so", options.ForDay(17).Variants["bob"].Plugin)
+
+	_, err = parse(t, []string{"-config", path, "-variant", "alice", "-isolate"}, nil)
+	assert.ErrorContains(t, err, "cannot be isolated")
+
+	path = writeConfig(t, `{"days": {"17": {"variants": {"alice": {"command": ["alice"], "plugin": "alice.so"}}}}}`)
+	_, err = parse(t, []string{"-config", path}, nil)
+	assert.ErrorContains(t, err, "either a command or a plugin")
+}
+
+func TestParamFlags(t *testing.T) {
+	path := writeConfig(t, `{"days": {"14": {"params": {"bounds": "11x7", "seconds": "10"}}}}`)
+
+	options, err := parse(t, []string{"-config", path, "-param", "seconds=20", "-param", "extra=1"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"bounds": "11x7", "seconds": "20", "extra": "1"}, options.ParamsFor(14))
+	assert.Equal(t, map[string]string{"seconds": "20", "extra": "1"}, options.ParamsFor(5))
+
+	_, err = parse(t, []string{"-param", "seconds"}, nil)
+	assert.Error(t, err)
+}
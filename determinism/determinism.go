@@ -0,0 +1,139 @@
+// Package determinism checks that a solution gives the same output every time it is run.
+// Go randomizes the order of every range over a map, so a solution whose answers depend on map
+// order will usually give a different answer within a few runs. Runs may also vary GOMAXPROCS,
+// to catch solutions which depend on how goroutines are scheduled.
+package determinism
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"runtime"
+
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+// The default number of times each solution is run.
+const DEFAULT_RUNS = 5
+
+// The default number of animation frames compared in each run.
+const DEFAULT_MAX_FRAMES = 1000
+
+/* ----------------------------- Check Definition ---------------------------- */
+
+// Options control how a solution is checked.
+type Options struct {
+	// The number of times to run the solution.
+	Runs int
+	// If true, each run uses a different GOMAXPROCS, cycling through 1, 2 and the number of CPUs.
+	VaryProcs bool
+	// The number of animation frames to compare in each run. Zero means no limit.
+	MaxFrames int
+}
+
+// Output is everything a single run of a solution produced.
+type Output struct {
+	Part1 string
+	Part2 string
+	// A hash of the visualization, or zero if the solution has none.
+	Visualization uint64
+	// A hash of the animation frames, or zero if the solution has none.
+	Animation uint64
+	// The GOMAXPROCS the run used.
+	Procs int
+}
+
+// Report is the result of checking a solution.
+type Report struct {
+	Outputs []Output
+	// A description of each way in which a run differed from the first.
+	Differences []string
+}
+
+// Deterministic returns true if every run gave the same output.
+func (r Report) Deterministic() bool {
+	return len(r.Differences) == 0
+}
+
+// Check runs the solution on the input several times and reports any output which differs between runs.
+// The context is passed on to the solution, so it may carry the puzzle's parameters.
+// GOMAXPROCS is restored once the check is done.
+func Check(ctx context.Context, s solution.Solution, input string, options Options) Report {
+	runs := max(options.Runs, 2)
+	procs := []int{runtime.GOMAXPROCS(0)}
+	if options.VaryProcs {
+		procs = []int{1, 2, runtime.NumCPU()}
+	}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+
+	report := Report{}
+	for i := 0; i < runs; i++ {
+		runtime.GOMAXPROCS(procs[i%len(procs)])
+		report.Outputs = append(report.Outputs, run(ctx, s, input, options.MaxFrames))
+	}
+
+	first := report.Outputs[0]
+	for i, output := range report.Outputs[1:] {
+		report.Differences = append(report.Differences, compare(first, output, i+2)...)
+	}
+	return report
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// run solves the puzzle once, and draws its visualization and animation if it has them.
+func run(ctx context.Context, s solution.Solution, input string, maxFrames int) Output {
+	output := Output{Procs: runtime.GOMAXPROCS(0)}
+	output.Part1, output.Part2 = solution.Solve(ctx, s, input)
+
+	if visualizer, ok := s.(solution.Visualizer); ok {
+		output.Visualization = digest(visualizer.Visualize(ctx, input))
+	}
+	if animator, ok := s.(solution.Animator); ok {
+		hash := fnv.New64a()
+		frames := 0
+		recorder := render.NewRecorder(1, 1)
+		recorder.Watch = func(frame func() *render.Grid) {
+			if maxFrames > 0 && frames >= maxFrames {
+				return
+			}
+			frames++
+			hash.Write([]byte(frame().ANSI()))
+		}
+		if err := animator.Animate(ctx, input, recorder); err != nil {
+			hash.Write([]byte(err.Error()))
+		}
+		output.Animation = hash.Sum64()
+	}
+	return output
+}
+
+// compare returns a description of each way in which the output of run n differs from the first run.
+func compare(first, output Output, n int) []string {
+	differences := make([]string, 0)
+	if first.Part1 != output.Part1 {
+		differences = append(differences, fmt.Sprintf("part 1: run 1 gave %s, run %d (GOMAXPROCS=%d) gave %s", first.Part1, n, output.Procs, output.Part1))
+	}
+	if first.Part2 != output.Part2 {
+		differences = append(differences, fmt.Sprintf("part 2: run 1 gave %s, run %d (GOMAXPROCS=%d) gave %s", first.Part2, n, output.Procs, output.Part2))
+	}
+	if first.Visualization != output.Visualization {
+		differences = append(differences, fmt.Sprintf("visualization: run %d (GOMAXPROCS=%d) differs from run 1", n, output.Procs))
+	}
+	if first.Animation != output.Animation {
+		differences = append(differences, fmt.Sprintf("animation: run %d (GOMAXPROCS=%d) differs from run 1", n, output.Procs))
+	}
+	return differences
+}
+
+// digest returns a hash of a grid's characters and colors, or of the error if it could not be drawn.
+func digest(grid *render.Grid, err error) uint64 {
+	hash := fnv.New64a()
+	if err != nil {
+		hash.Write([]byte(err.Error()))
+		return hash.Sum64()
+	}
+	hash.Write([]byte(grid.ANSI()))
+	return hash.Sum64()
+}
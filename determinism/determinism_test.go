@@ -0,0 +1,48 @@
+package determinism
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A puzzle whose first answer depends on map iteration order.
+type mapOrderPuzzle struct{}
+
+func (p mapOrderPuzzle) Solve(input string) (string, string) {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(input) {
+		words[word] = true
+	}
+	for word := range words {
+		return word, "fixed"
+	}
+	return "", "fixed"
+}
+
+// A puzzle which always gives the same answers.
+type stablePuzzle struct{}
+
+func (p stablePuzzle) Solve(input string) (string, string) {
+	return strings.ToUpper(input), "fixed"
+}
+
+func TestCheckMapOrder(t *testing.T) {
+	report := Check(context.Background(), mapOrderPuzzle{}, "a b c d e f g h i j k l m n o p", Options{Runs: 20})
+	assert.False(t, report.Deterministic())
+	assert.Len(t, report.Outputs, 20)
+	assert.Contains(t, report.Differences[0], "part 1: run 1 gave")
+}
+
+func TestCheckStable(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+	report := Check(context.Background(), stablePuzzle{}, "abc", Options{Runs: 3, VaryProcs: true})
+	assert.True(t, report.Deterministic())
+	assert.Equal(t, 1, report.Outputs[0].Procs)
+	assert.Equal(t, 2, report.Outputs[1].Procs)
+	assert.Equal(t, "ABC", report.Outputs[2].Part1)
+	assert.Equal(t, procs, runtime.GOMAXPROCS(0))
+}
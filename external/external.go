@@ -0,0 +1,197 @@
+// Package external runs solutions which are not part of this repository, such as a teammate's, so that
+// they can be run, verified and compared like the built-in ones. An external solution is either a
+// program, which is sent each puzzle as a JSON Request on its stdin and writes a JSON Response to its
+// stdout, or on Linux a Go plugin which exports a solution.Solution named Solution.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/params"
+)
+
+// The name of the symbol a Go plugin exports its Solution as.
+const PLUGIN_SYMBOL = "Solution"
+
+// ErrNoAnswer is the error of a part an external solution gave no answer for.
+var ErrNoAnswer = errors.New("no answer given")
+
+/* -------------------------- Request and Response -------------------------- */
+
+// Request is the puzzle an external program is asked to solve.
+// The parameters are those of the day's built-in solution, such as {"bounds": "101x103"}.
+type Request struct {
+	Day    int               `json:"day"`
+	Input  string            `json:"input"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Response is what an external program writes once it has solved a puzzle. Integer answers may be
+// JSON numbers or strings, and a part which could not be solved is null, with the reason in Error.
+type Response struct {
+	Part1 answer.Answer `json:"part1"`
+	Part2 answer.Answer `json:"part2"`
+	Error string        `json:"error,omitempty"`
+}
+
+// Answers returns the answers of the Response, with its error, or ErrNoAnswer, for any part it has no answer to.
+func (r Response) Answers() (answer.Answer, answer.Answer) {
+	err := ErrNoAnswer
+	if r.Error != "" {
+		err = errors.New(r.Error)
+	}
+	answers := [2]answer.Answer{r.Part1, r.Part2}
+	for i, solved := range answers {
+		if solved.Kind == answer.None {
+			answers[i] = answer.FromError(err)
+		}
+	}
+	return answers[0], answers[1]
+}
+
+/* --------------------- Process Definition and Methods --------------------- */
+
+// Process is a Solution which runs an external program for every puzzle it solves.
+// Anything the program writes to stderr is only shown if it fails.
+type Process struct {
+	// The day the program solves, which is sent with each Request.
+	Day int
+	// The program and its arguments, e.g. ["python3", "day17.py"].
+	Command []string
+	// The parameters sent with each Request, normally those of the day's built-in solution.
+	Declared []params.Param
+}
+
+// Solve returns the answers to the puzzle input as text.
+func (p Process) Solve(input string) (string, string) {
+	answer1, answer2 := p.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers runs the program on the puzzle input, and returns the answers it gives. The program is
+// killed if the context is cancelled. Parts fail with the error of a program which cannot be run,
+// fails, or writes an invalid Response.
+func (p Process) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	request := Request{Day: p.Day, Input: input, Params: params.FromContext(ctx, p.Declared).Map()}
+	response, err := p.Run(ctx, request)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
+	}
+	return response.Answers()
+}
+
+// Params declares the parameters the program is sent, so they can be set like those of the built-in solution.
+func (p Process) Params() []params.Param {
+	return p.Declared
+}
+
+// Run sends the request to a new instance of the program and reads its Response.
+func (p Process) Run(ctx context.Context, request Request) (Response, error) {
+	if len(p.Command) == 0 {
+		return Response{}, errors.New("no command to run")
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if message := lastLine(stderr.String()); message != "" {
+			return Response{}, fmt.Errorf("%s: %w: %s", p.Command[0], err, message)
+		}
+		return Response{}, fmt.Errorf("%s: %w", p.Command[0], err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return Response{}, fmt.Errorf("%s: invalid response: %w", p.Command[0], err)
+	}
+	return response, nil
+}
+
+/* ------------------------------ Serving in Go ----------------------------- */
+
+// Serve reads a Request from stdin, solves it with the Solution and writes the Response to stdout, so
+// that a solution written in Go can be run as an external program with a one line main function.
+// Anything the Solution prints is sent to stderr instead, and parameters it does not declare are ignored.
+func Serve(s solution.Solution) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+
+	response := Handle(os.Stdin, s)
+	if err := json.NewEncoder(stdout).Encode(response); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Handle reads a Request from the reader and returns the Solution's Response to it.
+func Handle(r io.Reader, s solution.Solution) Response {
+	var request Request
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return Response{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+
+	overrides := make(map[string]string)
+	if p, ok := s.(solution.Parameterized); ok {
+		for _, name := range params.Names(p.Params()) {
+			if value, ok := request.Params[name]; ok {
+				overrides[name] = value
+			}
+		}
+	}
+	ctx, err := solution.WithParams(context.Background(), s, overrides)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+
+	answer1, answer2 := solution.SolveAnswers(ctx, s, solution.NormalizeInput(s, request.Input))
+	response := Response{Part1: answer1, Part2: answer2}
+	for part, solved := range []answer.Answer{answer1, answer2} {
+		if solved.Err() != nil && response.Error == "" {
+			response.Error = fmt.Sprintf("part %d: %v", part+1, solved.Err())
+		}
+	}
+	return response
+}
+
+/* --------------------------------- Plugins -------------------------------- */
+
+// solutionOf returns the Solution a plugin's symbol refers to. The symbol may be a variable holding a
+// Solution, or a function returning one.
+func solutionOf(symbol any) (solution.Solution, error) {
+	switch s := symbol.(type) {
+	case *solution.Solution:
+		if *s != nil {
+			return *s, nil
+		}
+	case func() solution.Solution:
+		return s(), nil
+	case solution.Solution:
+		return s, nil
+	}
+	return nil, fmt.Errorf("%s is a %T, not a solution.Solution", PLUGIN_SYMBOL, symbol)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// lastLine returns the last line of output which is not blank.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,129 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/params"
+)
+
+// The environment variable which makes the test binary act as an external program, and how it behaves.
+const PROGRAM_ENV = "EXTERNAL_TEST_PROGRAM"
+
+var DECLARED = []params.Param{{Name: "size", Kind: params.Int, Default: "71", Usage: "The size of the grid."}}
+
+// A solution which answers with the length of its input and its size parameter, printing as it goes.
+type lengthSolution struct{}
+
+func (s lengthSolution) Solve(input string) (string, string) {
+	return solution.Solve(context.Background(), s, input)
+}
+
+func (s lengthSolution) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	fmt.Println("solving")
+	return answer.FromInt(len(input)), answer.FromInt(params.FromContext(ctx, DECLARED).Int("size"))
+}
+
+func (s lengthSolution) Params() []params.Param {
+	return DECLARED
+}
+
+// When run as an external program, the test binary behaves as the environment variable says, instead of running tests.
+func TestMain(m *testing.M) {
+	switch os.Getenv(PROGRAM_ENV) {
+	case "":
+		os.Exit(m.Run())
+	case "fail":
+		fmt.Fprintln(os.Stderr, "panic: index out of range")
+		os.Exit(2)
+	case "garbage":
+		fmt.Println("part 1 is 42")
+	case "sleep":
+		time.Sleep(time.Minute)
+	default:
+		Serve(lengthSolution{})
+	}
+	os.Exit(0)
+}
+
+// Returns a Process which runs the test binary in the given mode.
+func program(t *testing.T, mode string) Process {
+	t.Setenv(PROGRAM_ENV, mode)
+	return Process{Day: 18, Command: []string{os.Args[0]}, Declared: DECLARED}
+}
+
+func TestProcess(t *testing.T) {
+	p := program(t, "ok")
+	ctx, err := solution.WithParams(context.Background(), p, map[string]string{"size": "7"})
+	assert.NoError(t, err)
+
+	answer1, answer2 := solution.SolveAnswers(ctx, p, "hello")
+	assert.Equal(t, "5", answer1.String())
+	assert.Equal(t, "7", answer2.String())
+
+	// Without parameters, the defaults are sent
+	text1, text2 := p.Solve("hi")
+	assert.Equal(t, "2", text1)
+	assert.Equal(t, "71", text2)
+}
+
+func TestProcessFailures(t *testing.T) {
+	answer1, answer2 := program(t, "fail").SolveAnswers(context.Background(), "hello")
+	assert.ErrorContains(t, answer1.Err(), "exit status 2: panic: index out of range")
+	assert.Error(t, answer2.Err())
+
+	answer1, _ = program(t, "garbage").SolveAnswers(context.Background(), "hello")
+	assert.ErrorContains(t, answer1.Err(), "invalid response")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	answer1, _ = program(t, "sleep").SolveAnswers(ctx, "hello")
+	assert.Error(t, answer1.Err())
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	answer1, _ = Process{}.SolveAnswers(context.Background(), "hello")
+	assert.ErrorContains(t, answer1.Err(), "no command")
+}
+
+func TestHandle(t *testing.T) {
+	response := Handle(strings.NewReader(`{"day": 18, "input": "abc", "params": {"size": "9", "bytes": "12"}}`), lengthSolution{})
+	assert.Equal(t, Response{Part1: answer.FromInt(3), Part2: answer.FromInt(9)}, response)
+
+	response = Handle(strings.NewReader(`{"day": 18, "input": "abc", "params": {"size": "nine"}}`), lengthSolution{})
+	assert.Contains(t, response.Error, "invalid value")
+
+	response = Handle(strings.NewReader(`day 18`), lengthSolution{})
+	assert.Contains(t, response.Error, "invalid request")
+}
+
+func TestResponseAnswers(t *testing.T) {
+	answer1, answer2 := Response{Part1: answer.FromInt(42)}.Answers()
+	assert.Equal(t, "42", answer1.String())
+	assert.ErrorIs(t, answer2.Err(), ErrNoAnswer)
+
+	_, answer2 = Response{Part1: answer.FromInt(42), Error: "part 2: integer overflow"}.Answers()
+	assert.EqualError(t, answer2.Err(), "part 2: integer overflow")
+}
+
+func TestSolutionOf(t *testing.T) {
+	var variable solution.Solution = lengthSolution{}
+	for _, symbol := range []any{&variable, lengthSolution{}, func() solution.Solution { return variable }} {
+		s, err := solutionOf(symbol)
+		assert.NoError(t, err)
+		assert.Equal(t, variable, s)
+	}
+
+	var missing solution.Solution
+	_, err := solutionOf(&missing)
+	assert.Error(t, err)
+	_, err = solutionOf(42)
+	assert.ErrorContains(t, err, "not a solution.Solution")
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package external
+
+import (
+	"plugin"
+
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// LoadPlugin opens a Go plugin and returns the Solution it exports as Solution. The plugin must be
+// built with "go build -buildmode=plugin" against the same version of this module as the runner.
+func LoadPlugin(path string) (solution.Solution, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := p.Lookup(PLUGIN_SYMBOL)
+	if err != nil {
+		return nil, err
+	}
+	return solutionOf(symbol)
+}
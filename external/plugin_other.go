@@ -0,0 +1,15 @@
+//go:build !linux
+
+package external
+
+import (
+	"fmt"
+	"runtime"
+
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// LoadPlugin always fails, as Go plugins are only supported on Linux. Use an external program instead.
+func LoadPlugin(path string) (solution.Solution, error) {
+	return nil, fmt.Errorf("cannot load %s: Go plugins are not supported on %s", path, runtime.GOOS)
+}
@@ -0,0 +1,219 @@
+// Package isolate solves a puzzle in a child process, so that a solution which panics, exits, runs
+// out of memory or never finishes only fails its own day. The parent re-executes its own binary,
+// sends the puzzle as a Request on the child's stdin, and reads the child's Response from a pipe
+// (file descriptor 3), so anything the solution prints is captured separately. On Linux, the child
+// limits its own CPU time and address space before solving.
+package isolate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/trace"
+)
+
+// The command line argument which tells the runner it is a child process.
+const CHILD_COMMAND = "__isolated-child"
+
+// The file descriptor the child writes its Response to.
+const RESPONSE_FD = 3
+
+/* -------------------------- Request and Response -------------------------- */
+
+// Limits are the resources a child process may use. Zero means no limit.
+type Limits struct {
+	// CPU time, rounded up to a whole second.
+	CPU time.Duration `json:"cpu"`
+	// Address space, in bytes.
+	Memory int64 `json:"memory"`
+}
+
+// Request is the puzzle a child process is asked to solve.
+type Request struct {
+	Day     int               `json:"day"`
+	Input   string            `json:"input"`
+	Params  map[string]string `json:"params,omitempty"`
+	Workers int               `json:"workers,omitempty"`
+	Limits  Limits            `json:"limits"`
+	Trace   trace.Config      `json:"trace"`
+}
+
+// Response is what a child process reports once it has solved a puzzle.
+// The error of an answer which could not be found is kept in Errors, as answers marshal it as null.
+type Response struct {
+	Part1   answer.Answer `json:"part1"`
+	Part2   answer.Answer `json:"part2"`
+	Errors  [2]string     `json:"errors"`
+	Elapsed time.Duration `json:"elapsed_ns"`
+}
+
+// NewResponse returns the Response for a pair of answers.
+func NewResponse(answer1, answer2 answer.Answer, elapsed time.Duration) Response {
+	response := Response{Part1: answer1, Part2: answer2, Elapsed: elapsed}
+	for i, solved := range []answer.Answer{answer1, answer2} {
+		if solved.Err() != nil {
+			response.Errors[i] = solved.Err().Error()
+		}
+	}
+	return response
+}
+
+// Answers returns the answers of the Response, restoring the errors of answers which could not be found.
+func (r Response) Answers() (answer.Answer, answer.Answer) {
+	answers := [2]answer.Answer{r.Part1, r.Part2}
+	for i, message := range r.Errors {
+		if message != "" {
+			answers[i] = answer.FromError(errors.New(message))
+		}
+	}
+	return answers[0], answers[1]
+}
+
+/* --------------------------------- Outcome -------------------------------- */
+
+// Status is how a child process ended.
+type Status string
+
+const (
+	OK          Status = "ok"
+	Crashed     Status = "crashed"
+	OutOfMemory Status = "out of memory"
+	CPULimit    Status = "cpu limit exceeded"
+	TimedOut    Status = "timed out"
+)
+
+// Outcome is the result of running a child process.
+type Outcome struct {
+	Status   Status
+	Response Response
+	// Everything the child wrote to stdout and stderr.
+	Stdout string
+	Stderr string
+	// Why the child failed, if it did.
+	Err error
+}
+
+/* ----------------------------- Parent Process ----------------------------- */
+
+// Child describes how to start a child process.
+type Child struct {
+	// The executable to run, usually os.Executable().
+	Path string
+	// The arguments to run it with, which must make it call Serve.
+	Args []string
+	// Additional environment variables.
+	Env []string
+}
+
+// Run starts the child, sends it the request, and waits for it to finish. The child is killed when
+// the context is done, which is reported as a timeout.
+func (c Child) Run(ctx context.Context, request Request) Outcome {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return Outcome{Status: Crashed, Err: err}
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return Outcome{Status: Crashed, Err: err}
+	}
+	defer reader.Close()
+
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, c.Path, c.Args...)
+	cmd.Env = append(os.Environ(), c.Env...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.ExtraFiles = []*os.File{writer}
+
+	if err := cmd.Start(); err != nil {
+		writer.Close()
+		return Outcome{Status: Crashed, Err: err}
+	}
+	// Only the child holds the write end now, so the read ends when the child exits
+	writer.Close()
+	responses := make(chan []byte, 1)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		responses <- data
+	}()
+
+	waitErr := cmd.Wait()
+	data := <-responses
+	outcome := Outcome{Status: OK, Stdout: stdout.String(), Stderr: stderr.String()}
+
+	switch {
+	case ctx.Err() != nil:
+		outcome.Status, outcome.Err = TimedOut, ctx.Err()
+	case request.Limits.CPU > 0 && exceededCPU(cmd.ProcessState, request.Limits.CPU):
+		outcome.Status, outcome.Err = CPULimit, fmt.Errorf("used more than %v of CPU time", request.Limits.CPU)
+	case waitErr != nil && isOutOfMemory(outcome.Stderr):
+		outcome.Status, outcome.Err = OutOfMemory, fmt.Errorf("%s", lastLine(outcome.Stderr))
+	case waitErr != nil:
+		outcome.Status, outcome.Err = Crashed, fmt.Errorf("%v: %s", waitErr, lastLine(outcome.Stderr))
+	default:
+		if err := json.Unmarshal(data, &outcome.Response); err != nil {
+			outcome.Status, outcome.Err = Crashed, fmt.Errorf("no response: %w", err)
+		}
+	}
+	return outcome
+}
+
+/* ------------------------------ Child Process ----------------------------- */
+
+// Serve is the entry point of a child process. It reads a Request from stdin, applies its limits,
+// and writes the Response returned by solve to the response pipe.
+// Any failure to do so is fatal, which the parent reports as a crash.
+func Serve(solve func(Request) Response) {
+	request := Request{}
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fatal(fmt.Errorf("reading request: %w", err))
+	}
+	if err := Apply(request.Limits); err != nil {
+		fatal(err)
+	}
+
+	response := solve(request)
+	pipe := os.NewFile(RESPONSE_FD, "response")
+	if err := json.NewEncoder(pipe).Encode(response); err != nil {
+		fatal(fmt.Errorf("writing response: %w", err))
+	}
+	pipe.Close()
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// fatal prints the error and exits the child process.
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "isolate:", err)
+	os.Exit(1)
+}
+
+// lastLine returns the most useful line of a crashed child's stderr: the panic message if it
+// panicked, otherwise the last line written.
+func lastLine(stderr string) string {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return "no output"
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+			return line
+		}
+	}
+	return lines[len(lines)-1]
+}
+
+// isOutOfMemory returns true if a child's stderr shows it failed to allocate memory.
+func isOutOfMemory(stderr string) bool {
+	return strings.Contains(stderr, "out of memory") || strings.Contains(stderr, "cannot allocate memory")
+}
@@ -0,0 +1,111 @@
+package isolate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util/answer"
+)
+
+// The environment variable which makes the test binary act as a child, and how the child behaves.
+const CHILD_ENV = "ISOLATE_TEST_CHILD"
+
+// Set when the tests are built with the race detector.
+var raceEnabled = false
+
+// When run as a child, the test binary solves the request as the environment variable says, instead of running tests.
+func TestMain(m *testing.M) {
+	mode := os.Getenv(CHILD_ENV)
+	if mode == "" {
+		os.Exit(m.Run())
+	}
+
+	Serve(func(request Request) Response {
+		switch mode {
+		case "panic":
+			panic("index out of range")
+		case "fatal":
+			log.Fatalf("Failed to convert %s to an integer.", request.Input)
+		case "memory":
+			data := make([]byte, 1<<30)
+			data[len(data)-1] = 1
+		case "spin":
+			for {
+			}
+		case "sleep":
+			time.Sleep(time.Minute)
+		}
+		fmt.Println("solving day", request.Day)
+		return NewResponse(answer.FromInt(len(request.Input)), answer.FromError(answer.ErrOverflow), time.Millisecond)
+	})
+	os.Exit(0)
+}
+
+// Runs the test binary as a child in the given mode.
+func runChild(mode string, timeout time.Duration, limits Limits) Outcome {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	child := Child{Path: os.Args[0], Env: []string{CHILD_ENV + "=" + mode}}
+	return child.Run(ctx, Request{Day: 5, Input: "hello", Limits: limits})
+}
+
+func TestOK(t *testing.T) {
+	outcome := runChild("ok", 10*time.Second, Limits{})
+	assert.Equal(t, OK, outcome.Status)
+	assert.NoError(t, outcome.Err)
+	assert.Equal(t, "solving day 5\n", outcome.Stdout)
+
+	answer1, answer2 := outcome.Response.Answers()
+	assert.Equal(t, answer.FromInt(5), answer1)
+	assert.EqualError(t, answer2.Err(), "integer overflow")
+	assert.Equal(t, time.Millisecond, outcome.Response.Elapsed)
+}
+
+func TestCrashes(t *testing.T) {
+	outcome := runChild("panic", 10*time.Second, Limits{})
+	assert.Equal(t, Crashed, outcome.Status)
+	assert.ErrorContains(t, outcome.Err, "panic: index out of range")
+
+	outcome = runChild("fatal", 10*time.Second, Limits{})
+	assert.Equal(t, Crashed, outcome.Status)
+	assert.ErrorContains(t, outcome.Err, "exit status 1")
+	assert.ErrorContains(t, outcome.Err, "Failed to convert hello to an integer.")
+}
+
+func TestTimeout(t *testing.T) {
+	outcome := runChild("sleep", 500*time.Millisecond, Limits{})
+	assert.Equal(t, TimedOut, outcome.Status)
+}
+
+func TestLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("resource limits are only supported on Linux")
+	}
+
+	// The race detector reserves more address space than the memory limit allows
+	if !raceEnabled {
+		outcome := runChild("memory", 10*time.Second, Limits{Memory: 256 << 20})
+		assert.Equal(t, OutOfMemory, outcome.Status, outcome.Stderr)
+	}
+
+	outcome := runChild("spin", 10*time.Second, Limits{CPU: time.Second})
+	assert.Equal(t, CPULimit, outcome.Status, outcome.Stderr)
+
+	// Being killed without using the CPU time, as by the OOM killer, is not a CPU limit
+	// The child waits for a request on stdin, which is held open until it is killed
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), CHILD_ENV+"=sleep")
+	stdin, _ := cmd.StdinPipe()
+	assert.NoError(t, cmd.Start())
+	cmd.Process.Kill()
+	cmd.Wait()
+	stdin.Close()
+	assert.False(t, exceededCPU(cmd.ProcessState, time.Second))
+}
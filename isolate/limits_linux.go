@@ -0,0 +1,43 @@
+//go:build linux
+
+package isolate
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// Apply limits the CPU time and address space of the current process.
+// The process is sent SIGXCPU once it has used its CPU time, and SIGKILL a second later.
+func Apply(limits Limits) error {
+	if limits.CPU > 0 {
+		seconds := uint64((limits.CPU + time.Second - 1) / time.Second)
+		if err := syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: seconds, Max: seconds + 1}); err != nil {
+			return err
+		}
+	}
+	if limits.Memory > 0 {
+		memory := uint64(limits.Memory)
+		if err := syscall.Setrlimit(syscall.RLIMIT_AS, &syscall.Rlimit{Cur: memory, Max: memory}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exceededCPU returns true if the process was killed for using all of its CPU time.
+// SIGKILL is also sent by the OOM killer or by hand, so it only counts if the process used its limit.
+func exceededCPU(state *os.ProcessState, limit time.Duration) bool {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return false
+	}
+	switch status.Signal() {
+	case syscall.SIGXCPU:
+		return true
+	case syscall.SIGKILL:
+		return state.UserTime()+state.SystemTime() >= limit
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+package isolate
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// Apply returns an error if any limits are set, as resource limits are only supported on Linux.
+func Apply(limits Limits) error {
+	if limits.CPU > 0 || limits.Memory > 0 {
+		return errors.New("resource limits are only supported on Linux")
+	}
+	return nil
+}
+
+// exceededCPU always returns false, as CPU limits are only supported on Linux.
+func exceededCPU(state *os.ProcessState, limit time.Duration) bool {
+	return false
+}
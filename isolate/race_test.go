@@ -0,0 +1,7 @@
+//go:build race
+
+package isolate
+
+func init() {
+	raceEnabled = true
+}
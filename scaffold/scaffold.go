@@ -0,0 +1,147 @@
+// Package scaffold creates the package for a new day's puzzle from the solution template,
+// using the title and example input read from the puzzle's description page.
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/aoc"
+)
+
+// The module path solution packages are imported from.
+const MODULE = "shaneholland.dev/aoc-2024"
+
+// The icon given to a new day in the solution map.
+const DEFAULT_ICON = "⭐"
+
+// Width of the comment banners at the top of each file.
+const BANNER_WIDTH = 80
+
+// ErrDayExists is returned when scaffolding a day whose package already exists.
+var ErrDayExists = errors.New("solution package already exists")
+
+/* --------------------------- Package Scaffolding -------------------------- */
+
+// New creates the package for a puzzle under root/solution by copying root/solution/template.
+// The banner, package name and test data are filled in from the puzzle, and the day is added to
+// the solution map. The path of the new package is returned.
+func New(root string, puzzle aoc.PuzzlePage, icon string) (string, error) {
+	name := fmt.Sprintf("day-%02d", puzzle.Day)
+	template := filepath.Join(root, "solution", "template")
+	dir := filepath.Join(root, "solution", name)
+
+	if _, err := os.Stat(dir); err == nil {
+		return dir, fmt.Errorf("%w: %s", ErrDayExists, dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return dir, err
+	}
+
+	pkg := fmt.Sprintf("day%02d", puzzle.Day)
+	for _, file := range []string{"main.go", "main_test.go"} {
+		source, err := os.ReadFile(filepath.Join(template, file))
+		if err != nil {
+			return dir, err
+		}
+		code := strings.ReplaceAll(string(source), "package dayXX", "package "+pkg)
+		if file == "main.go" {
+			code = replaceBanner(code, fmt.Sprintf("--- Day %d: %s ---", puzzle.Day, puzzle.Title))
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(code), 0o644); err != nil {
+			return dir, err
+		}
+	}
+
+	example := []byte(puzzle.Example)
+	if puzzle.Example == "" {
+		var err error
+		if example, err = os.ReadFile(filepath.Join(template, "test-data.txt")); err != nil {
+			return dir, err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "test-data.txt"), example, 0o644); err != nil {
+		return dir, err
+	}
+
+	return dir, Register(filepath.Join(root, "solution", "solution-map.go"), puzzle.Day, puzzle.Title, icon)
+}
+
+// Register adds a day to the solution map source file at path, keeping the entries in order of day.
+func Register(path string, day int, title, icon string) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if icon == "" {
+		icon = DEFAULT_ICON
+	}
+
+	name := fmt.Sprintf("day-%02d", day)
+	imported := fmt.Sprintf("\tday%02d %q", day, MODULE+"/solution/"+name)
+	entry := fmt.Sprintf("\t%q: {day%02d.Puzzle{}, %s, %s},", name, day, strconv.Quote(icon), strconv.Quote(title))
+
+	lines := strings.Split(string(source), "\n")
+	if slices.Contains(lines, imported) {
+		return fmt.Errorf("%w: %s is already registered", ErrDayExists, name)
+	}
+
+	lines, err = insertSorted(lines, "import (", imported)
+	if err != nil {
+		return err
+	}
+	lines, err = insertSorted(lines, "var Solutions = map[string]Solver{", entry)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0o644)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// Banner returns a title centered within a comment, such as the titles at the top of each file.
+func Banner(title string) string {
+	inner := BANNER_WIDTH - 4
+	left := (inner - len([]rune(title)) + 1) / 2
+	right := inner - len([]rune(title)) - left
+	return "/*" + strings.Repeat(" ", max(left, 1)) + title + strings.Repeat(" ", max(right, 1)) + "*/"
+}
+
+// replaceBanner replaces the title line of the comment box at the top of a source file.
+func replaceBanner(code, title string) string {
+	lines := strings.SplitN(code, "\n", 3)
+	if len(lines) < 3 {
+		return code
+	}
+	lines[1] = Banner(title)
+	return strings.Join(lines, "\n")
+}
+
+// insertSorted inserts a line into the block opened by the start line, before the first line in
+// the block which sorts after it.
+func insertSorted(lines []string, start, line string) ([]string, error) {
+	open := slices.Index(lines, start)
+	if open == -1 {
+		return nil, fmt.Errorf("could not find %q in the solution map", start)
+	}
+
+	i := open + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == ")" || trimmed == "}" || (trimmed != "" && lines[i] > line) {
+			break
+		}
+	}
+	return slices.Insert(lines, i, line), nil
+}
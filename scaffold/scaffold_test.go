@@ -0,0 +1,74 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/aoc"
+)
+
+// Returns a temporary copy of the parts of the repository the scaffolder reads.
+func newTestRoot(t *testing.T) string {
+	root := t.TempDir()
+	files := []string{"template/main.go", "template/main_test.go", "template/test-data.txt", "solution-map.go"}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join("..", "solution", file))
+		assert.NoError(t, err)
+		path := filepath.Join(root, "solution", file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, data, 0o644))
+	}
+	return root
+}
+
+func TestNew(t *testing.T) {
+	root := newTestRoot(t)
+	puzzle := aoc.PuzzlePage{Day: 19, Title: "Linen Layout", Example: "r, wr, b\n\nbrwrr\n"}
+
+	dir, err := New(root, puzzle, "🧣")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "solution", "day-19"), dir)
+
+	main, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	lines := strings.Split(string(main), "\n")
+	assert.Equal(t, "/*                        --- Day 19: Linen Layout ---                        */", lines[1])
+	assert.Contains(t, string(main), "package day19\n")
+
+	test, _ := os.ReadFile(filepath.Join(dir, "main_test.go"))
+	assert.Contains(t, string(test), "package day19\n")
+
+	example, _ := os.ReadFile(filepath.Join(dir, "test-data.txt"))
+	assert.Equal(t, puzzle.Example, string(example))
+
+	registry, _ := os.ReadFile(filepath.Join(root, "solution", "solution-map.go"))
+	assert.Contains(t, string(registry), "\tday19 \"shaneholland.dev/aoc-2024/solution/day-19\"\n)")
+	assert.Contains(t, string(registry), "\t\"day-19\": {day19.Puzzle{}, \"🧣\", \"Linen Layout\"},\n}")
+
+	_, err = New(root, puzzle, "🧣")
+	assert.True(t, errors.Is(err, ErrDayExists))
+}
+
+func TestRegisterKeepsOrder(t *testing.T) {
+	root := newTestRoot(t)
+	path := filepath.Join(root, "solution", "solution-map.go")
+
+	assert.NoError(t, Register(path, 25, "Code Chronicle", ""))
+	assert.NoError(t, Register(path, 20, "Race Condition", ""))
+
+	registry, _ := os.ReadFile(path)
+	source := string(registry)
+	assert.Less(t, strings.Index(source, "\"day-18\":"), strings.Index(source, "\"day-20\":"))
+	assert.Less(t, strings.Index(source, "\"day-20\":"), strings.Index(source, "\"day-25\":"))
+	assert.Contains(t, source, "{day25.Puzzle{}, \"⭐\", \"Code Chronicle\"}")
+
+	assert.True(t, errors.Is(Register(path, 20, "Race Condition", ""), ErrDayExists))
+}
+
+func TestBanner(t *testing.T) {
+	assert.Equal(t, "/*                                Solution Map                                */", Banner("Solution Map"))
+	assert.Equal(t, "/*                       --- Day 6: Guard Gallivant ---                       */", Banner("--- Day 6: Guard Gallivant ---"))
+}
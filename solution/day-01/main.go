@@ -4,23 +4,37 @@
 package day01
 
 import (
-	"log"
-	"regexp"
+	"context"
+	"fmt"
+	"log/slog"
 	"sort"
-	"strconv"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parse"
+	"shaneholland.dev/aoc-2024/util/trace"
 )
 
+var tracer = trace.New("day-01")
+
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	left, right := parseInput(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
+	left, right, err := parseInput(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
+	}
 	return part1(left, right), part2(left, right)
 }
 
 // Part 1: Find the distance between the two arrays.
-func part1(left, right []int) string {
+func part1(left, right []int) answer.Answer {
 	max := min(len(left), len(right))
 
 	distance := 0
@@ -28,11 +42,11 @@ func part1(left, right []int) string {
 		distance += util.AbsInt(left[i] - right[i])
 	}
 
-	return strconv.Itoa(distance)
+	return answer.FromInt(distance)
 }
 
 // Part 2: Find the similarity between the two arrays.
-func part2(left, right []int) string {
+func part2(left, right []int) answer.Answer {
 	similarity := 0
 
 	index := 0
@@ -44,22 +58,25 @@ func part2(left, right []int) string {
 			}
 			index++
 		}
-		// fmt.Printf("Num: %d, Matches: %d\n", num, matches)
+		if tracer.Enabled(slog.LevelDebug) {
+			tracer.Debug("similarity", slog.Int("num", num), slog.Int("matches", matches))
+		}
 		similarity += matches * num
 		index -= matches
 	}
 
-	return strconv.Itoa(similarity)
+	return answer.FromInt(similarity)
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
 // Function to parse the input into two arrays of integers
-func parseInput(input string) (left []int, right []int) {
-	lines := util.GetLines(input)
-
-	for _, line := range lines {
-		a, b := parseLine(line)
+func parseInput(input string) (left []int, right []int, err error) {
+	for _, line := range util.NonEmptyLines(input) {
+		a, b, err := parseLine(line)
+		if err != nil {
+			return nil, nil, err
+		}
 		left = append(left, a)
 		right = append(right, b)
 	}
@@ -67,26 +84,16 @@ func parseInput(input string) (left []int, right []int) {
 	sort.Ints(left)
 	sort.Ints(right)
 
-	return left, right
+	return left, right, nil
 }
 
 // Function to parse a line of input into two integers
-func parseLine(line string) (a, b int) {
-	pattern := `(\d+)\s+(\d+)`
-
-	re := regexp.MustCompile(pattern)
-
-	matches := re.FindStringSubmatch(line)
+func parseLine(line util.Line) (a, b int, err error) {
+	numbers := parse.Ints(line.Text)
 
-	// Check if matches were found
-	if len(matches) > 0 {
-		// matches[0] is the entire match
-		// matches[1:] are the captured groups
-		a = util.AtoI(matches[1])
-		b = util.AtoI(matches[2])
-	} else {
-		log.Fatalf("No match found for line: %s", line)
+	if len(numbers) != 2 {
+		return 0, 0, fmt.Errorf("no match found for line %d: %s", line.Number, line.Text)
 	}
 
-	return a, b
+	return numbers[0], numbers[1], nil
 }
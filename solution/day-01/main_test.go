@@ -1,6 +1,8 @@
 package day01
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +28,23 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Writing the parsed lists back out as input gives the same lists.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		left, right, err := parseInput(input)
+		if err != nil {
+			return
+		}
+		lines := make([]string, len(left))
+		for i := range left {
+			lines[i] = fmt.Sprintf("%d   %d", left[i], right[i])
+		}
+
+		reparsedLeft, reparsedRight, err := parseInput(strings.Join(lines, "\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, left, reparsedLeft)
+		assert.Equal(t, right, reparsedRight)
+	})
+}
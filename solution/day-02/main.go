@@ -4,61 +4,76 @@
 package day02
 
 import (
-	"strconv"
+	"context"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parallel"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
 }
 
-// Part 1: Find the number of safe reports.
-func part1(input string) string {
-	safeReports := 0
-
-	for _, line := range util.GetLines(input) {
-		report := parseLine(line)
-
-		if isSafe(report) {
-			safeReports++
-		}
+// SolveAnswers solves the puzzle, checking the reports on the number of workers allowed by the context.
+// Both parts fail if a report cannot be parsed.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	reports, err := parseReports(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
 	}
-	return strconv.Itoa(safeReports)
+	return part1(ctx, reports), part2(ctx, reports)
+}
+
+// Part 1: Find the number of safe reports.
+func part1(ctx context.Context, reports [][]int) answer.Answer {
+	return answer.FromInt(parallel.Count(ctx, reports, isSafe))
 }
 
 // Part 2: Find the number of safe reports with a dampener.
-func part2(input string) string {
-	safeReports := 0
+func part2(ctx context.Context, reports [][]int) answer.Answer {
+	return answer.FromInt(parallel.Count(ctx, reports, isSafeWithDampener))
+}
 
-	for _, line := range util.GetLines(input) {
-		report := parseLine(line)
+/* ----------------------------- Helper Methods ----------------------------- */
 
-		if isSafeWithDampener(report) {
-			safeReports++
+// Parse each non-empty line of the input into a report of levels.
+func parseReports(input string) ([][]int, error) {
+	reports := make([][]int, 0)
+	for _, line := range util.NonEmptyLines(input) {
+		levels, err := parseLine(line)
+		if err != nil {
+			return nil, err
 		}
+		reports = append(reports, levels)
 	}
-	return strconv.Itoa(safeReports)
+	return reports, nil
 }
 
-/* ----------------------------- Helper Methods ----------------------------- */
-
 // Parse the line into a slice of integers.
-func parseLine(line string) []int {
+func parseLine(line util.Line) ([]int, error) {
 	var levels []int
-	for _, level := range strings.Split(line, " ") {
-		levelNum := util.AtoI(level)
+	for _, level := range strings.Split(line.Text, " ") {
+		levelNum, err := util.ParseInt(level)
+		if err != nil {
+			return nil, util.InputError{Line: line.Number, Column: 1, Message: err.Error()}
+		}
 		levels = append(levels, levelNum)
 	}
-	return levels
+	return levels, nil
 }
 
 // Check if the levels are safe.
 // A level is safe if the difference between it and the next level is between 1 and 3.
 func isSafe(levels []int) bool {
+	// A single level, left after the dampener removes one of two, has no differences to be unsafe
+	if len(levels) < 2 {
+		return true
+	}
 	ascending := levels[0] < levels[1]
 	for i, level := range levels[1:] {
 
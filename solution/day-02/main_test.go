@@ -1,6 +1,8 @@
 package day02
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +28,22 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Writing the parsed reports back out as input gives the same reports.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		reports, err := parseReports(input)
+		if err != nil {
+			return
+		}
+		lines := make([]string, len(reports))
+		for i, report := range reports {
+			lines[i] = strings.Trim(fmt.Sprint(report), "[]")
+		}
+
+		reparsed, err := parseReports(strings.Join(lines, "\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, reports, reparsed)
+	})
+}
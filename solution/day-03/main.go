@@ -4,33 +4,41 @@
 package day03
 
 import (
-	"fmt"
+	"context"
 	"regexp"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle. A part fails if one of its operands is too large to be an integer.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
 // Part 1: Return the sum of the products where mul(a, b) is the product of a and b.
-func part1(input string) string {
-	result := 0
-
+func part1(input string) answer.Answer {
 	puzzleInput := strings.ReplaceAll(input, "\n", "")
 
-	result += sumProducts(puzzleInput)
-	return fmt.Sprintf("%d", result)
+	result, err := sumProducts(puzzleInput)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(result)
 }
 
 
 // Part 2: Return the sum of the products where mul(a, b) is the product of a and b.
 // Only do this when the string when the instruction "do()" was last given, rather than "don't()".
-func part2(input string) string {
+func part2(input string) answer.Answer {
 	result := 0
 	puzzleInput := strings.ReplaceAll(input, "\n", "")
 
@@ -44,7 +52,11 @@ func part2(input string) string {
 		substring := puzzleInput[:index]
 		// Update the line to be the substring after the "don't()" instruction
 		puzzleInput = puzzleInput[index:]
-		result += sumProducts(substring)
+		sum, err := sumProducts(substring)
+		if err != nil {
+			return answer.FromError(err)
+		}
+		result += sum
 
 		index = strings.Index(puzzleInput, "do()")
 		if index != -1 {
@@ -55,26 +67,44 @@ func part2(input string) string {
 		}
 	}
 
-	return fmt.Sprintf("%d", result)
+	return answer.FromInt(result)
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
-func sumProducts(input string) int {
+// Pattern matching a mul(a, b) instruction, compiled once rather than on every call.
+var mulPattern = regexp.MustCompile(`(mul\((\d+),(\d+)\))`)
+
+// Returns the sum of the products of every mul(a, b) instruction in the input.
+func sumProducts(input string) (int, error) {
+	muls, err := parseMuls(input)
+	if err != nil {
+		return 0, err
+	}
+
 	sum := 0
-	pattern := `(mul\((\d+),(\d+)\))`
+	for _, operands := range muls {
+		sum += operands[0] * operands[1]
+	}
 
-	re := regexp.MustCompile(pattern)
+	return sum, nil
+}
 
-	matches := re.FindAllStringSubmatch(input, -1)
+// Returns the operands of every mul(a, b) instruction in the input, or an error if one is too large to be an integer.
+func parseMuls(input string) ([][2]int, error) {
+	muls := make([][2]int, 0)
 
-	if len(matches) > 0 {
-		for _, match := range matches {
-			num1 := util.AtoI(match[2])
-			num2 := util.AtoI(match[3])
-			sum += num1 * num2
+	for _, match := range mulPattern.FindAllStringSubmatch(input, -1) {
+		num1, err := util.ParseInt(match[2])
+		if err != nil {
+			return nil, err
+		}
+		num2, err := util.ParseInt(match[3])
+		if err != nil {
+			return nil, err
 		}
+		muls = append(muls, [2]int{num1, num2})
 	}
 
-	return sum
+	return muls, nil
 }
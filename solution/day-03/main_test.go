@@ -1,6 +1,7 @@
 package day03
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,22 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Writing the parsed instructions back out as input gives the same instructions.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		muls, err := parseMuls(input)
+		if err != nil {
+			return
+		}
+		program := ""
+		for _, mul := range muls {
+			program += fmt.Sprintf("mul(%d,%d)", mul[0], mul[1])
+		}
+
+		reparsed, err := parseMuls(program)
+		assert.NoError(t, err)
+		assert.Equal(t, muls, reparsed)
+	})
+}
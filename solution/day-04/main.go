@@ -4,37 +4,48 @@
 package day04
 
 import (
-	"fmt"
+	"context"
 	"math"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the word search cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
+	wordSearch, err := parseWordSearch(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
+	}
+	return part1(wordSearch), part2(wordSearch)
 }
 
 // Part 1: Count the number of instances of the string "XMAS" (forward or reversed) in the word puzzle
-func part1(input string) string {
+func part1(wordSearch []string) answer.Answer {
 	instances := 0
 	// Horizontal
-	instances += countInstancesXmas(util.GetLines(input))
+	instances += countInstancesXmas(wordSearch)
 	// Vertical
-	instances += countInstancesXmas(util.GetColumns(input))
+	instances += countInstancesXmas(util.GetColumns(strings.Join(wordSearch, "\n")))
 	// Diagonal SW-NE
-	instances += countInstancesXmas(getDiagonalsSwNe(input))
+	instances += countInstancesXmas(getDiagonalsSwNe(wordSearch))
 	// Diagonal NW-SE
-	instances += countInstancesXmas(getDiagonalsNwSe(input))
+	instances += countInstancesXmas(getDiagonalsNwSe(wordSearch))
 
-	return fmt.Sprintf("%d", instances)
+	return answer.FromInt(instances)
 }
 
 // Part 2: Count the number of instances where MAS appears (forward or reversed) in an X pattern in the word puzzle
-func part2(input string) string {
-	matrix := util.GetLines(input)
+func part2(wordSearch []string) answer.Answer {
+	matrix := wordSearch
 	instances := 0
 
 	for y := 0; y < len(matrix)-2; y++ {
@@ -48,7 +59,7 @@ func part2(input string) string {
 		}
 	}
 
-	return fmt.Sprintf("%d", instances)
+	return answer.FromInt(instances)
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
@@ -81,45 +92,36 @@ func countInstancesXmas(lines []string) int {
 }
 
 // Returns the diagonals of the input string array from the SW to NE
-func getDiagonalsSwNe(input string) []string {
-	var diagonalLines []string
-	lines := util.GetLines(input)
-
-	for i := 0; i < len(lines); i++ {
-		var diagonalLine1 string
-		var diagonalLine2 string
-		for j := 0; j < len(lines); j++ {
-			if i+j < len(lines) {
-				diagonalLine1 += string(lines[i+j][len(lines[i+j])-(j+1)])
-			}
-			if i > 0 && j+i < len(lines) {
-				diagonalLine2 += string(lines[j][len(lines[i+j])-(j+i+1)])
-			}
+func getDiagonalsSwNe(lines []string) []string {
+	height, width := len(lines), len(lines[0])
+	diagonalLines := make([]string, height+width-1)
+
+	// Letters on the same SW-NE diagonal share the sum of their coordinates
+	for y := height - 1; y >= 0; y-- {
+		for x := 0; x < width; x++ {
+			diagonalLines[x+y] += string(lines[y][x])
 		}
-		diagonalLines = append(diagonalLines, []string{diagonalLine1, diagonalLine2}...)
 	}
 
 	return diagonalLines
 }
 
 // Returns the diagonals of the input string array from the NW to SE
-func getDiagonalsNwSe(input string) []string {
-	var diagonalLines []string
-	lines := util.GetLines(input)
-
-	for i := 0; i < len(lines); i++ {
-		var diagonalLine1 string
-		var diagonalLine2 string
-		for j := 0; j < len(lines); j++ {
-			if i+j < len(lines) {
-				diagonalLine1 += string(lines[i+j][j])
-			}
-			if i > 0 && j+i < len(lines) {
-				diagonalLine2 += string(lines[j][j+i])
-			}
+func getDiagonalsNwSe(lines []string) []string {
+	height, width := len(lines), len(lines[0])
+	diagonalLines := make([]string, height+width-1)
+
+	// Letters on the same NW-SE diagonal share the difference of their coordinates
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			diagonalLines[x-y+height-1] += string(lines[y][x])
 		}
-		diagonalLines = append(diagonalLines, []string{diagonalLine1, diagonalLine2}...)
 	}
 
 	return diagonalLines
 }
+
+// Returns the rows of the word search, or an error if they differ in width.
+func parseWordSearch(input string) ([]string, error) {
+	return util.RequireGrid(input)
+}
@@ -26,3 +26,34 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// The small example from the puzzle is wider than it is tall.
+func TestRectangular(t *testing.T) {
+	solver := Puzzle{}
+	answer1, _ := solver.Solve("..X...\n.SAMX.\n.A..A.\nXMAS.S\n.X....\n")
+
+	assert.Equal(t, "4", answer1)
+}
+
+// Transposing a word search swaps its rows with its columns and mirrors its diagonals, so
+// neither count changes.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		wordSearch, err := parseWordSearch(input)
+		if err != nil {
+			return
+		}
+		transposed := make([]string, len(wordSearch[0]))
+		for x := range transposed {
+			column := make([]byte, len(wordSearch))
+			for y, row := range wordSearch {
+				column[y] = row[x]
+			}
+			transposed[x] = string(column)
+		}
+
+		assert.Equal(t, part1(wordSearch), part1(transposed))
+		assert.Equal(t, part2(wordSearch), part2(transposed))
+	})
+}
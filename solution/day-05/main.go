@@ -4,38 +4,50 @@
 package day05
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parse"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	printQueue := parsePrintQueue(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the print queue cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
+	printQueue, err := parsePrintQueue(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
+	}
 	return part1(printQueue), part2(printQueue)
 }
 
 // Part 1: Find the sum of the middle pages of all valid print jobs.
-func part1(printQueue PrintQueue) string {
+func part1(printQueue PrintQueue) answer.Answer {
 	middlePageSum := 0
 	for _, printJob := range printQueue.GetValidPrintJobs() {
 		middlePageSum += printJob.Pages[(len(printJob.Pages)-1)/2]
 	}
 
-	return fmt.Sprintf("%d", middlePageSum)
+	return answer.FromInt(middlePageSum)
 }
 
 // Part 2: Find the sum of the middle pages of all corrected print jobs.
-func part2(printQueue PrintQueue) string {
+func part2(printQueue PrintQueue) answer.Answer {
 	middlePageSum := 0
 	for _, printJob := range printQueue.GetCorrectedPrintJobs() {
 		middlePageSum += printJob.Pages[(len(printJob.Pages)-1)/2]
 	}
 
-	return fmt.Sprintf("%d", middlePageSum)
+	return answer.FromInt(middlePageSum)
 }
 
 /* --------------------------- PrintJob Definition -------------------------- */
@@ -114,15 +126,28 @@ func (pq *PrintQueue) correctPrintJob(printJob PrintJob) PrintJob {
 /* ----------------------------- Helper Methods ----------------------------- */
 
 // parsePrintQueue returns a PrintQueue from the input string.
-func parsePrintQueue(input string) PrintQueue {
-	lines := util.GetLines(input)
-	pageBreak := slices.Index(lines, "")
+// An error is returned if the page rules or print jobs are missing, or are not lists of page numbers.
+func parsePrintQueue(input string) (PrintQueue, error) {
+	sections := parse.Sections(input)
+	if len(sections) != 2 {
+		return PrintQueue{}, fmt.Errorf("expected page rules and print jobs separated by a blank line, found %d sections", len(sections))
+	}
 	pageRules := make(map[int][]int)
 
 	// Parse the page rules
-	for _, line := range lines[:pageBreak] {
-		first := util.AtoI(strings.Split(line, "|")[0])
-		second := util.AtoI(strings.Split(line, "|")[1])
+	for _, line := range util.GetLines(sections[0]) {
+		pages := parse.Fields(line, "|")
+		if len(pages) != 2 {
+			return PrintQueue{}, fmt.Errorf("invalid page rule %q, expected X|Y", line)
+		}
+		first, err := util.ParseInt(pages[0])
+		if err != nil {
+			return PrintQueue{}, err
+		}
+		second, err := util.ParseInt(pages[1])
+		if err != nil {
+			return PrintQueue{}, err
+		}
 
 		if !slices.Contains(pageRules[first], second) {
 			pageRules[first] = append(pageRules[first], second)
@@ -131,15 +156,18 @@ func parsePrintQueue(input string) PrintQueue {
 
 	printJobs := make([]PrintJob, 0)
 	// Parse the print jobs
-	for _, line := range lines[pageBreak+1:] {
+	for _, line := range util.GetLines(sections[1]) {
 		printJob := make([]int, 0)
 		for _, page := range strings.Split(line, ",") {
-			pageInt := util.AtoI(page)
+			pageInt, err := util.ParseInt(page)
+			if err != nil {
+				return PrintQueue{}, err
+			}
 			printJob = append(printJob, pageInt)
 		}
 
 		printJobs = append(printJobs, PrintJob{Pages: printJob})
 	}
 
-	return PrintQueue{Jobs: printJobs, PageRules: pageRules}
+	return PrintQueue{Jobs: printJobs, PageRules: pageRules}, nil
 }
@@ -1,6 +1,10 @@
 package day05
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +30,28 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Writing the parsed rules and print jobs back out as input gives the same print queue.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		printQueue, err := parsePrintQueue(input)
+		if err != nil {
+			return
+		}
+		rules := make([]string, 0)
+		for _, first := range slices.Sorted(maps.Keys(printQueue.PageRules)) {
+			for _, second := range printQueue.PageRules[first] {
+				rules = append(rules, fmt.Sprintf("%d|%d", first, second))
+			}
+		}
+		jobs := make([]string, len(printQueue.Jobs))
+		for i, job := range printQueue.Jobs {
+			jobs[i] = strings.ReplaceAll(strings.Trim(fmt.Sprint(job.Pages), "[]"), " ", ",")
+		}
+
+		reparsed, err := parsePrintQueue(strings.Join(rules, "\n") + "\n\n" + strings.Join(jobs, "\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, printQueue, reparsed)
+	})
+}
@@ -4,38 +4,88 @@
 package day06
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/bitset"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, reporting the progress of part 2 to the context's progress.Reporter,
+// and testing obstacles on the number of workers allowed by the context.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	return part1(input), part2(ctx, input)
+}
+
+// Visualize draws the lab with the guard's patrol path highlighted.
+func (d Puzzle) Visualize(_ context.Context, input string) (*render.Grid, error) {
+	patrolMap, err := parsePatrolMap(input)
+	if err != nil {
+		return nil, err
+	}
+	start := patrolMap.GuardPosition
+	path := patrolMap.PointsVisited()
+
+	return patrolMap.Render().
+		Highlight(path, render.Style{Char: 'X', Color: render.Yellow, Name: "guard path"}).
+		Highlight([]util.Point{start}, render.Style{Char: '^', Color: render.Red, Name: "start"}), nil
+}
+
+// Animate records the guard's patrol, one frame per step.
+func (d Puzzle) Animate(_ context.Context, input string, recorder *render.Recorder) error {
+	patrolMap, err := parsePatrolMap(input)
+	if err != nil {
+		return err
+	}
+	patrolMap.Recorder = recorder
+	patrolMap.PointsVisited()
+	return nil
 }
 
 // Part 1: Find the number of points visited before the guard leaves the area
-func part1(input string) string {
-	patrolMap := parsePatrolMap(input)
-	return fmt.Sprintf("%d", patrolMap.CountPointsVisited())
+func part1(input string) answer.Answer {
+	patrolMap, err := parsePatrolMap(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(patrolMap.CountPointsVisited())
 }
 
 // Part 2: Find the number of obstacles that can cause the guard to loop
-func part2(input string) string {
-	patrolMap := parsePatrolMap(input)
-	return fmt.Sprintf("%d", patrolMap.CountPositionsWhichCauseALoop())
+func part2(ctx context.Context, input string) answer.Answer {
+	patrolMap, err := parsePatrolMap(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	patrolMap.Progress = progress.FromContext(ctx)
+	return answer.FromInt(patrolMap.CountPositionsWhichCauseALoop(ctx))
 }
 
 /* -------------------- PatrolMap Definition and Methods -------------------- */
 
 // PatrolMap represents a map of a guard's patrol path.
 // It contains the guard's position, obstacles, direction, and bounds.
+// If a Recorder is set, a frame is recorded for every step of the patrol.
+// If a Progress reporter is set, the obstacle positions tested for loops are reported to it.
 type PatrolMap struct {
 	GuardPosition util.Point
 	Grid          [][]bool
 	Direction     int
 	Bounds        util.Point
+	Recorder      *render.Recorder
+	Progress      *progress.Reporter
 }
 
 // PointsVisited returns the number of points visited before the guard leaves the area.
@@ -50,18 +100,18 @@ func (pm *PatrolMap) CountPointsVisited() int {
 
 // Returns the unique set of points visisted by the guard
 func (pm *PatrolMap) PointsVisited() []util.Point {
-	visited := map[util.Point]int{pm.GuardPosition: pm.Direction}
+	// Track each visited position, and the directions it was visited in to detect loops
+	positions := bitset.NewGrid(pm.Bounds.X, pm.Bounds.Y)
+	visited := newDirectionGrids(pm.Bounds)
+	positions.Set(pm.GuardPosition)
+	visited[pm.Direction].Set(pm.GuardPosition)
 
 	for {
 		lastPos := pm.GuardPosition
 		pm.Move()
 
-		if pm.GuardPosition.X < 0 || pm.GuardPosition.Y < 0 || pm.GuardPosition.X >= pm.Bounds.X || pm.GuardPosition.Y >= pm.Bounds.Y {
-			points := make([]util.Point, 0)
-			for p := range visited {
-				points = append(points, p)
-			}
-			return points
+		if !positions.InBounds(pm.GuardPosition) {
+			return slices.Collect(positions.Points())
 		}
 
 		if pm.Grid[pm.GuardPosition.Y][pm.GuardPosition.X] {
@@ -71,33 +121,42 @@ func (pm *PatrolMap) PointsVisited() []util.Point {
 		}
 
 		// Loop check
-		if dir, ok := visited[pm.GuardPosition]; ok && dir == pm.Direction {
+		if visited[pm.Direction].Test(pm.GuardPosition) {
 			return make([]util.Point, 0)
 		}
 
-		visited[pm.GuardPosition] = pm.Direction
+		positions.Set(pm.GuardPosition)
+		visited[pm.Direction].Set(pm.GuardPosition)
+
+		if pm.Recorder != nil {
+			pm.Recorder.Record(func() *render.Grid { return pm.renderPatrol(positions) })
+		}
 	}
 }
 
-// Returns the unique set of points visisted by the guard
+// Returns true if the guard's patrol ends in a loop
 func (pm *PatrolMap) LoopCheck() bool {
-	visited := map[util.Point]int{pm.GuardPosition: pm.Direction}
+	return pm.loopCheck(newDirectionGrids(pm.Bounds))
+}
 
+// Returns true if the guard's patrol ends in a loop.
+// The obstacles hit while travelling in each direction are tracked in the given grids, which must be clear.
+func (pm *PatrolMap) loopCheck(hit [4]*bitset.Grid) bool {
 	for {
 		lastPos := pm.GuardPosition
 		pm.Move()
 
-		if pm.GuardPosition.X < 0 || pm.GuardPosition.Y < 0 || pm.GuardPosition.X >= pm.Bounds.X || pm.GuardPosition.Y >= pm.Bounds.Y {
+		if !hit[pm.Direction].InBounds(pm.GuardPosition) {
 			return false
 		}
 
 		if pm.Grid[pm.GuardPosition.Y][pm.GuardPosition.X] {
 			// Loop check
-			if dir, ok := visited[pm.GuardPosition]; ok && dir == pm.Direction {
+			if hit[pm.Direction].Test(pm.GuardPosition) {
 				return true
 			}
 
-			visited[pm.GuardPosition] = pm.Direction
+			hit[pm.Direction].Set(pm.GuardPosition)
 			pm.Direction = (pm.Direction + 1) % 4
 			pm.GuardPosition = lastPos
 			continue
@@ -120,26 +179,74 @@ func (pm *PatrolMap) Move() {
 }
 
 // PositionsWhichCauseALoop returns the number of obstacle positions that can cause the guard to loop.
-func (pm *PatrolMap) CountPositionsWhichCauseALoop() int {
-	loopObstacles := make([]util.Point, 0)
+// Positions are tested in parallel, on the number of workers allowed by the context. Testing a position
+// moves the guard and adds an obstacle, so each worker tests positions on its own copy of the map.
+func (pm *PatrolMap) CountPositionsWhichCauseALoop(ctx context.Context) int {
 	originalPosition := pm.GuardPosition
+	originalDirection := pm.Direction
 
 	// Only test positions we know the guard will normally visit
 	testPositions := pm.PointsVisited()
-	for _, pos := range testPositions {
+	pm.GuardPosition, pm.Direction = originalPosition, originalDirection
+	pm.Progress.Start("testing obstacles", len(testPositions))
+
+	type worker struct {
+		patrolMap *PatrolMap
+		hit       [4]*bitset.Grid
+	}
+	newWorker := func() worker {
+		return worker{patrolMap: pm.clone(), hit: newDirectionGrids(pm.Bounds)}
+	}
+
+	loops := parallel.MapState(ctx, len(testPositions), newWorker, func(w worker, i int) bool {
+		pos := testPositions[i]
 		// Reset the map
-		pm.GuardPosition = originalPosition
-		pm.Direction = NORTH
+		w.patrolMap.GuardPosition = originalPosition
+		w.patrolMap.Direction = NORTH
+		for _, grid := range w.hit {
+			grid.Reset()
+		}
 		// Add the obstacle
-		pm.Grid[pos.Y][pos.X] = true
+		w.patrolMap.Grid[pos.Y][pos.X] = true
 		// Loop discovered
-		if pm.LoopCheck() {
-			loopObstacles = append(loopObstacles, util.Point{X: pos.X, Y: pos.Y})
+		loop := w.patrolMap.loopCheck(w.hit)
+		w.patrolMap.Grid[pos.Y][pos.X] = false
+		pm.Progress.Add(1)
+		return loop
+	})
+
+	return parallel.Reduce(loops, 0, func(count int, loop bool) int {
+		if loop {
+			return count + 1
 		}
-		pm.Grid[pos.Y][pos.X] = false
+		return count
+	})
+}
+
+// Returns a copy of the map, with its own grid, which does not record or report progress.
+func (pm *PatrolMap) clone() *PatrolMap {
+	grid := make([][]bool, len(pm.Grid))
+	for y, row := range pm.Grid {
+		grid[y] = slices.Clone(row)
 	}
+	return &PatrolMap{GuardPosition: pm.GuardPosition, Grid: grid, Direction: pm.Direction, Bounds: pm.Bounds}
+}
 
-	return len(loopObstacles)
+// Returns a grid of the lab, where obstructions are drawn as '#'.
+func (pm *PatrolMap) Render() *render.Grid {
+	return render.NewGrid(pm.Bounds.X, pm.Bounds.Y, func(p util.Point) render.Style {
+		if pm.Grid[p.Y][p.X] {
+			return render.Style{Char: '#', Color: render.Gray, Name: "obstruction"}
+		}
+		return render.Style{Char: '.'}
+	})
+}
+
+// Returns a grid of the lab, with the points visited so far and the guard's current position highlighted.
+func (pm *PatrolMap) renderPatrol(visited *bitset.Grid) *render.Grid {
+	return pm.Render().
+		Highlight(slices.Collect(visited.Points()), render.Style{Char: 'X', Color: render.Yellow, Name: "guard path"}).
+		Highlight([]util.Point{pm.GuardPosition}, render.Style{Char: '^', Color: render.Red, Name: "guard"})
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
@@ -152,28 +259,46 @@ const (
 	WEST
 )
 
+// newDirectionGrids returns a grid for each direction, used to track state which depends on the direction of travel.
+func newDirectionGrids(bounds util.Point) [4]*bitset.Grid {
+	grids := [4]*bitset.Grid{}
+	for i := range grids {
+		grids[i] = bitset.NewGrid(bounds.X, bounds.Y)
+	}
+	return grids
+}
+
 // parsePatrolMap returns a PatrolMap from the input string.
-func parsePatrolMap(input string) PatrolMap {
+// An error is returned if the rows of the lab differ in width, or it does not have exactly one guard.
+func parsePatrolMap(input string) (PatrolMap, error) {
+	lines, err := util.RequireGrid(input)
+	if err != nil {
+		return PatrolMap{}, err
+	}
 	pos := util.Point{X: 0, Y: 0}
-	bounds := len(util.GetLines(input))
-	grid := make([][]bool, bounds)
+	guards := 0
+	grid := make([][]bool, len(lines))
 
-	for y, line := range util.GetLines(input) {
+	for y, line := range lines {
 		grid[y] = make([]bool, len(line))
 		for x, cell := range line {
 			switch cell {
 			case '^':
 				pos = util.Point{X: x, Y: y}
+				guards++
 			case '#':
 				grid[y][x] = true
 			}
 		}
 	}
+	if guards != 1 {
+		return PatrolMap{}, fmt.Errorf("expected one guard (^) in the lab, found %d", guards)
+	}
 
 	return PatrolMap{
 		GuardPosition: pos,
 		Grid:          grid,
 		Direction:     NORTH,
-		Bounds:        util.Point{X: bounds, Y: bounds},
-	}
+		Bounds:        util.Point{X: len(lines[0]), Y: len(lines)},
+	}, nil
 }
@@ -1,10 +1,12 @@
 package day06
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/progress"
 )
 
 const PART_1_EXPECTED = "41"
@@ -26,3 +28,31 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+func TestProgress(t *testing.T) {
+	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
+	reporter := progress.New()
+	solver := Puzzle{}
+	_, answer2 := solver.SolveAnswers(progress.WithReporter(context.Background(), reporter), testInput)
+
+	assert.Equal(t, PART_2_EXPECTED, answer2.String())
+	snapshot := reporter.Snapshot()
+	assert.Equal(t, "testing obstacles", snapshot.Phase)
+	assert.Equal(t, 41, snapshot.Total)
+	assert.Equal(t, 41, snapshot.Current)
+}
+
+// The guard only ever walks through the lab's free cells.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		patrolMap, err := parsePatrolMap(input)
+		if err != nil {
+			return
+		}
+		for _, p := range patrolMap.PointsVisited() {
+			inLab := p.X >= 0 && p.X < patrolMap.Bounds.X && p.Y >= 0 && p.Y < patrolMap.Bounds.Y
+			assert.True(t, inLab && !patrolMap.Grid[p.Y][p.X], "visited %v", p)
+		}
+	})
+}
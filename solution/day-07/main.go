@@ -4,44 +4,42 @@
 package day07
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parallel"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
 }
 
-// Part 1: Find the sum of all test values that pass the equation.
-func part1(input string) string {
-	sum := 0
-
-	for _, line := range util.GetLines(input) {
-		equation := parseEquation(line, false)
-		if equation.Test() {
-			sum += equation.TestValue
-		}
+// SolveAnswers solves the puzzle, testing the equations on the number of workers allowed by the context.
+// Both parts fail if an equation cannot be parsed.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	equations, err := parseEquations(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
 	}
-	return fmt.Sprintf("%d", sum)
+	return part1(ctx, equations), part2(ctx, equations)
 }
 
-// Part 2: Find the sum of all test values that pass the equation with concatenation.
-func part2(input string) string {
-	sum := 0
+// Part 1: Find the sum of all test values that pass the equation.
+func part1(ctx context.Context, equations []Equation) answer.Answer {
+	return answer.FromInt(sumPassing(ctx, equations, false))
+}
 
-	for _, line := range util.GetLines(input) {
-		equation := parseEquation(line, true)
-		if equation.Test() {
-			sum += equation.TestValue
-		}
-	}
-	return fmt.Sprintf("%d", sum)
+// Part 2: Find the sum of all test values that pass the equation with concatenation.
+func part2(ctx context.Context, equations []Equation) answer.Answer {
+	return answer.FromInt(sumPassing(ctx, equations, true))
 }
 
 /* --------------------- Equation Definition and Methods -------------------- */
@@ -64,8 +62,10 @@ func (eq *Equation) Test() bool {
 			newValues = append(newValues, value+component)
 			newValues = append(newValues, value*component)
 			if eq.ConcatEnabled {
-				concat := util.AtoI(strconv.Itoa(value) + strconv.Itoa(component))
-				newValues = append(newValues, concat)
+				// A concatenation too large for an int is larger than any test value, so it is dropped
+				if concat, err := strconv.Atoi(strconv.Itoa(value) + strconv.Itoa(component)); err == nil {
+					newValues = append(newValues, concat)
+				}
 			}
 		}
 		possibleValues = newValues
@@ -75,16 +75,55 @@ func (eq *Equation) Test() bool {
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
+// Returns the sum of the test values of every equation which passes, testing each equation in parallel.
+func sumPassing(ctx context.Context, equations []Equation, concat bool) int {
+	return parallel.MapReduce(ctx, equations, func(equation Equation) int {
+		equation.ConcatEnabled = concat
+		if equation.Test() {
+			return equation.TestValue
+		}
+		return 0
+	}, 0, func(sum, value int) int { return sum + value })
+}
+
+// Parse an Equation from each line of the input, without concatenation.
+func parseEquations(input string) ([]Equation, error) {
+	equations := make([]Equation, 0)
+	for _, line := range util.GetLines(input) {
+		equation, err := parseEquation(line, false)
+		if err != nil {
+			return nil, err
+		}
+		equations = append(equations, equation)
+	}
+	return equations, nil
+}
+
 // Parse an Equation from a string.
 // The equation is formatted as "{TestValue}: {Component1} {Component2} ...".
 // If concat is true, then the equation will also test concatenation of components.
-func parseEquation(input string, concat bool) Equation {
-	testValue := util.AtoI(strings.Split(input, ": ")[0])
-	components := make([]int, 0)
+// Components must not be negative, as a negative number cannot be concatenated.
+func parseEquation(input string, concat bool) (Equation, error) {
+	testValue, components, ok := strings.Cut(input, ": ")
+	if !ok {
+		return Equation{}, fmt.Errorf("invalid equation %q, expected {TestValue}: {Components}", input)
+	}
+	equation := Equation{Components: make([]int, 0), ConcatEnabled: concat}
 
-	for _, component := range strings.Split(strings.Split(input, ": ")[1], " ") {
-		components = append(components, util.AtoI(component))
+	var err error
+	if equation.TestValue, err = util.ParseInt(testValue); err != nil {
+		return Equation{}, err
+	}
+	for _, component := range strings.Split(components, " ") {
+		n, err := util.ParseInt(component)
+		if err != nil {
+			return Equation{}, err
+		}
+		if n < 0 {
+			return Equation{}, fmt.Errorf("invalid equation %q, components must not be negative", input)
+		}
+		equation.Components = append(equation.Components, n)
 	}
 
-	return Equation{TestValue: testValue, Components: components, ConcatEnabled: concat}
-}
\ No newline at end of file
+	return equation, nil
+}
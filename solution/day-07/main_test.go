@@ -1,6 +1,8 @@
 package day07
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +28,22 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Writing the parsed equations back out as input gives the same equations.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		equations, err := parseEquations(input)
+		if err != nil {
+			return
+		}
+		lines := make([]string, len(equations))
+		for i, equation := range equations {
+			lines[i] = fmt.Sprintf("%d: %s", equation.TestValue, strings.Trim(fmt.Sprint(equation.Components), "[]"))
+		}
+
+		reparsed, err := parseEquations(strings.Join(lines, "\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, equations, reparsed)
+	})
+}
@@ -4,31 +4,41 @@
 package day08
 
 import (
-	"fmt"
+	"context"
 	"math"
 	"regexp"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	antennaMap := parseAntennaMap(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the antenna map cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
+	antennaMap, err := parseAntennaMap(input)
+	if err != nil {
+		return answer.FromError(err), answer.FromError(err)
+	}
 
 	return part1(antennaMap), part2(antennaMap)
 }
 
 // Part 1: Count the number of antinodes in the antenna map.
-func part1(antennaMap AntennaMap) string {
+func part1(antennaMap AntennaMap) answer.Answer {
 	antinodes := antennaMap.CountAntinodes(false)
-	return fmt.Sprintf("%d", antinodes)
+	return answer.FromInt(antinodes)
 }
 
 // Part 2: Count the number of antinodes in the antenna map with resonant harmonics.
-func part2(antennaMap AntennaMap) string {
+func part2(antennaMap AntennaMap) answer.Answer {
 	antinodes := antennaMap.CountAntinodes(true)
-	return fmt.Sprintf("%d", antinodes)
+	return answer.FromInt(antinodes)
 }
 
 /* -------------------- AntennaMap Definition and Methods ------------------- */
@@ -114,10 +124,13 @@ func (a *AntennaMap) getAllAntinodes(p1, p2 util.Point) []util.Point {
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
-// Parses an antenna map from the input string
-func parseAntennaMap(input string) AntennaMap {
+// Parses an antenna map from the input string, or returns an error if the map is not rectangular
+func parseAntennaMap(input string) (AntennaMap, error) {
 	antennas := make(map[string][]util.Point)
-	inputLines := util.GetLines(input)
+	inputLines, err := util.RequireGrid(input)
+	if err != nil {
+		return AntennaMap{}, err
+	}
 
 	pattern := `(\d|\w)`
 	re := regexp.MustCompile(pattern)
@@ -130,13 +143,17 @@ func parseAntennaMap(input string) AntennaMap {
 			antennas[line[x:x+1]] = append(antennas[line[x:x+1]], util.Point{X: x, Y: y})
 		}
 	}
-	return AntennaMap{Antennas: antennas, Bounds: util.Point{X: len(inputLines[0]), Y: len(inputLines)}}
+	return AntennaMap{Antennas: antennas, Bounds: util.Point{X: len(inputLines[0]), Y: len(inputLines)}}, nil
 }
 
 // Returns the greatest common divisor of two numbers
 func gcd(num1 int, num2 int) int {
 	n1 := int(math.Max(math.Abs(float64(num1)), math.Abs(float64(num2))))
 	n2 := int(math.Min(math.Abs(float64(num1)), math.Abs(float64(num2))))
+	// Antennas in the same row or column are apart in only one direction
+	if n2 == 0 {
+		return n1
+	}
 
 	for {
 		if n1%n2 == 0 {
@@ -26,3 +26,29 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Antennas in the same row are apart in only one direction.
+func TestSameRow(t *testing.T) {
+	solver := Puzzle{}
+	answer1, answer2 := solver.Solve("aa...\n.....\n")
+
+	assert.Equal(t, "1", answer1)
+	assert.Equal(t, "5", answer2)
+}
+
+// Every antenna is found at a cell holding its own frequency.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		antennaMap, err := parseAntennaMap(input)
+		if err != nil {
+			return
+		}
+		lines := util.GetLines(input)
+		for frequency, antennas := range antennaMap.Antennas {
+			for _, antenna := range antennas {
+				assert.Equal(t, frequency, lines[antenna.Y][antenna.X:antenna.X+1])
+			}
+		}
+	})
+}
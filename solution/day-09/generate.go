@@ -0,0 +1,22 @@
+package day09
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+/* -------------------------------- Generator ------------------------------- */
+
+// Generate returns a random disk map of size digits. Files take 1 to 9 blocks, and the free space
+// after each file 0 to 9 blocks, as in the real puzzle input.
+func (d Puzzle) Generate(rng *rand.Rand, size int) (string, map[string]string) {
+	diskMap := strings.Builder{}
+	for i := 0; i < size; i++ {
+		if i%2 == 0 {
+			diskMap.WriteByte(byte('1' + rng.IntN(9)))
+		} else {
+			diskMap.WriteByte(byte('0' + rng.IntN(10)))
+		}
+	}
+	return diskMap.String(), nil
+}
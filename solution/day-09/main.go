@@ -4,30 +4,62 @@
 package day09
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/interval"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
+// The disk map is a single line of digits, so any stray whitespace is removed.
+func (d Puzzle) WhitespacePolicy() util.WhitespacePolicy {
+	return util.TrimWhitespace
+}
+
+// Animate records the file based defrag, one frame per file considered.
+func (d Puzzle) Animate(_ context.Context, input string, recorder *render.Recorder) error {
+	diskMap, err := parseDiskMap(input)
+	if err != nil {
+		return err
+	}
+	diskMap.Recorder = recorder
+	diskMap.BlockDefrag(true)
+	return nil
+}
+
 // Part 1: Calculate the checksum of the disk map after a simple defrag.
-func part1(input string) string {
-	diskMap := parseDiskMap(input)
+func part1(input string) answer.Answer {
+	diskMap, err := parseDiskMap(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	diskMap.BlockDefrag(false)
-	return fmt.Sprintf("%d", diskMap.Checksum())
+	return answer.FromInt(diskMap.Checksum())
 }
 
 // Part 2: Calculate the checksum of the disk map after a file based defrag.
-func part2(input string) string {
-	diskMap := parseDiskMap(input)
+func part2(input string) answer.Answer {
+	diskMap, err := parseDiskMap(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	diskMap.BlockDefrag(true)
-	return fmt.Sprintf("%d", diskMap.Checksum())
+	return answer.FromInt(diskMap.Checksum())
 }
 
 /* ----------------------------- File Definition ---------------------------- */
@@ -39,9 +71,12 @@ type File struct {
 
 /* --------------------- DiskMap Definition and Methods --------------------- */
 // DiskMap represents a disk map with files and free space.
+// Files are keyed by their position, and FreeSpace holds the free block ranges in position order.
+// If a Recorder is set, a frame is recorded each time a file is considered for defragmentation.
 type DiskMap struct {
 	Files map[int]File
-	FreeSpace map[int]int
+	FreeSpace *interval.Set
+	Recorder *render.Recorder
 }
 
 
@@ -63,17 +98,6 @@ func (diskMap *DiskMap) sortedFilePositions(reverse bool) []int {
 	return filePositions
 }
 
-// Returns list of free space positions sorted by position.
-func (diskMap *DiskMap) sortedFreeSpacePositions() []int {
-	freeSpacePositions := make([]int, 0)
-	for pos := range diskMap.FreeSpace {
-		freeSpacePositions = append(freeSpacePositions, pos)
-	}
-	sort.Ints(freeSpacePositions)
-
-	return freeSpacePositions
-}
-
 // Returns the checksum of the disk map.
 func (diskMap *DiskMap) Checksum() int {
 	checksum := 0
@@ -94,68 +118,112 @@ func (diskMap *DiskMap) Checksum() int {
 // Otherwise, it will move the blocks that can fit in the free space.
 func (diskMap *DiskMap) BlockDefrag(wholeFiles bool) {
 	for _, pos := range diskMap.sortedFilePositions(true) {
+		diskMap.Recorder.Record(diskMap.Render)
+
 		fileId := diskMap.Files[pos].Id
 		fileSize := diskMap.Files[pos].Size
-		for _, freePos := range diskMap.sortedFreeSpacePositions() {
-			freeSize := diskMap.FreeSpace[freePos]
 
-			if freePos > pos {
-				if wholeFiles {
-					break
-				}
+		if wholeFiles {
+			// Move the file to the first free space which can accommodate it, if it is to the left
+			if free, ok := diskMap.FreeSpace.FirstFit(fileSize); ok && free.Start < pos {
+				diskMap.moveFileToFreeSpace(pos, free.Start)
+			}
+			continue
+		}
+
+		for {
+			free, ok := diskMap.FreeSpace.First()
+			if !ok || free.Start > pos {
+				diskMap.Recorder.Record(diskMap.Render)
 				return
 			}
 
 			// Free space can accommodate the file blocks
-			if freeSize >= fileSize {
-				diskMap.moveFileToFreeSpace(pos, freePos)
+			if free.Len() >= fileSize {
+				diskMap.moveFileToFreeSpace(pos, free.Start)
 				break
-			} else if !wholeFiles {
-				// Free space cannot accommodate the file blocks, move the ones that can fit
-				fileSize -= freeSize
-				diskMap.Files[pos] = File{Id: fileId, Size: fileSize}
-
-				diskMap.Files[freePos] = File{Id: fileId, Size: freeSize}
-				delete(diskMap.FreeSpace, freePos)
 			}
+			// Free space cannot accommodate the file blocks, move the ones that can fit
+			fileSize -= free.Len()
+			diskMap.Files[pos] = File{Id: fileId, Size: fileSize}
+
+			diskMap.Files[free.Start] = File{Id: fileId, Size: free.Len()}
+			diskMap.FreeSpace.Remove(free.Start, free.End)
 		}
 	}
+	diskMap.Recorder.Record(diskMap.Render)
 }
 
 // Moves a file to a free space position
 func (diskMap *DiskMap) moveFileToFreeSpace(filePos int, freePos int) {
-	freeSize := diskMap.FreeSpace[freePos]
 	fileSize := diskMap.Files[filePos].Size
 
-	diskMap.FreeSpace[freePos+fileSize] = freeSize - fileSize
-	delete(diskMap.FreeSpace, freePos)
+	diskMap.FreeSpace.Remove(freePos, freePos+fileSize)
 	diskMap.Files[freePos] = File{Id: diskMap.Files[filePos].Id, Size: fileSize}
 	delete(diskMap.Files, filePos)
-	if freeSize-fileSize == 0 {
-		delete(diskMap.FreeSpace, freePos+fileSize)
+}
+
+// Returns a grid of the disk, wrapped into rows of DISK_RENDER_WIDTH blocks, with each file in its own color.
+func (diskMap *DiskMap) Render() *render.Grid {
+	size := 0
+	for pos, file := range diskMap.Files {
+		size = max(size, pos+file.Size)
+	}
+	for free := range diskMap.FreeSpace.All() {
+		size = max(size, free.End)
+	}
+
+	// Map each block to the id of the file occupying it, or -1 for free space
+	blocks := make([]int, size)
+	for i := range blocks {
+		blocks[i] = -1
+	}
+	for pos, file := range diskMap.Files {
+		for i := 0; i < file.Size; i++ {
+			blocks[pos+i] = file.Id
+		}
 	}
+
+	height := (size + DISK_RENDER_WIDTH - 1) / DISK_RENDER_WIDTH
+	return render.NewGrid(DISK_RENDER_WIDTH, height, func(p util.Point) render.Style {
+		block := p.Y*DISK_RENDER_WIDTH + p.X
+		if block >= size || blocks[block] == -1 {
+			return render.Style{Char: '.'}
+		}
+		return render.Style{Char: rune('0' + blocks[block]%10), Color: render.Palette(blocks[block])}
+	})
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
-// Parses a disk map from the input string
- func parseDiskMap(input string) DiskMap {
+// The number of blocks drawn in each row when rendering the disk.
+const DISK_RENDER_WIDTH = 100
+
+// Parses a disk map from the input string, or returns an error if it contains anything but digits.
+// Every file must take at least one block, as files are keyed by their position.
+ func parseDiskMap(input string) (DiskMap, error) {
 	diskMap := DiskMap{
 		Files:     make(map[int]File),
-		FreeSpace: make(map[int]int),
+		FreeSpace: interval.New(),
 	}
 
 	position := 0
 
 	for i, c := range input {
-		size := util.AtoI(string(c))
+		if c < '0' || c > '9' {
+			return DiskMap{}, util.InputError{Line: 1, Column: i + 1, Message: fmt.Sprintf("unexpected %q in disk map", c)}
+		}
+		size := int(c - '0')
 
 		if (i % 2) == 0 {
+			if size == 0 {
+				return DiskMap{}, util.InputError{Line: 1, Column: i + 1, Message: fmt.Sprintf("file %d has no blocks", i/2)}
+			}
 			diskMap.Files[position] = File{Id: i / 2, Size: size}
 		} else if size > 0 {
-			diskMap.FreeSpace[position] = size
+			diskMap.FreeSpace.Insert(position, position+size)
 		}
 		position += size
 	}
-	return diskMap
+	return diskMap, nil
 }
\ No newline at end of file
@@ -26,3 +26,24 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Every other digit of the disk map is a file, and the files hold as many blocks as those digits.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		diskMap, err := parseDiskMap(input)
+		if err != nil {
+			return
+		}
+		blocks := 0
+		for i := 0; i < len(input); i += 2 {
+			blocks += int(input[i] - '0')
+		}
+		for _, file := range diskMap.Files {
+			blocks -= file.Size
+		}
+
+		assert.Len(t, diskMap.Files, (len(input)+1)/2)
+		assert.Zero(t, blocks)
+	})
+}
@@ -4,21 +4,49 @@
 package day10
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
+// Visualize draws the topographic map with every plot on a complete hiking trail highlighted.
+func (d Puzzle) Visualize(_ context.Context, input string) (*render.Grid, error) {
+	grid, err := parseGrid(input)
+	if err != nil {
+		return nil, err
+	}
+	trailMap := NewTopographicMap(grid)
+	trailHeads := make([]util.Point, 0)
+	for _, trailHead := range trailMap.TrailHeads {
+		trailHeads = append(trailHeads, util.Point{X: trailHead.X, Y: trailHead.Y})
+	}
+
+	return render.FromLines(util.GetLines(input), nil).
+		Highlight(trailMap.TrailPlots(), render.Style{Color: render.Green, Name: "trail"}).
+		Highlight(trailHeads, render.Style{Color: render.Cyan, Name: "trailhead"}), nil
+}
+
 // Part 1: Find the number of 9-height plots reachable from all trailHeads.
-func part1(input string) string {
-	grid := parseGrid(input)
+func part1(input string) answer.Answer {
+	grid, err := parseGrid(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	trailMap := NewTopographicMap(grid)
 
 	scoreSum := 0
@@ -26,12 +54,15 @@ func part1(input string) string {
 		trailScore := trailMap.score(trailhead)
 		scoreSum += trailScore
 	}
-	return fmt.Sprintf("%d", scoreSum)
+	return answer.FromInt(scoreSum)
 }
 
 // Part 2: Find the number of distinct hiking trails which begin at all trailHeads.
-func part2(input string) string {
-	grid := parseGrid(input)
+func part2(input string) answer.Answer {
+	grid, err := parseGrid(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	trailMap := NewTopographicMap(grid)
 
 	ratingSum := 0
@@ -40,7 +71,7 @@ func part2(input string) string {
 		ratingSum += trailScore
 	}
 
-	return fmt.Sprintf("%d", ratingSum)
+	return answer.FromInt(ratingSum)
 }
 
 /* ----------------------------- Plot Definition ---------------------------- */
@@ -53,12 +84,12 @@ type Plot struct {
 
 /* ------------------ TopographicMap Definition and Methods ----------------- */
 // TopographicMap represents a graph of trails and their corresponding heights.
-// It contains a list of trailheads, a map of trail edges, and the bounds of the map.
+// It contains a list of trailheads, a map of trail edges, and the width and height of the map.
 // Trail Heads are the starting points of trails, with a height of 0.
 type TopographicMap struct {
 	TrailHeads  []Plot
 	TrailEdges  map[Plot]map[Plot]int
-	TrailBounds int
+	TrailBounds util.Point
 }
 
 // score returns the number of 9-height plots reachable from the starting plot.
@@ -102,11 +133,43 @@ func (t TopographicMap) rating(start Plot) int {
 	return rating
 }
 
+// TrailPlots returns the position of every plot which is part of a hiking trail from a trailhead to a peak.
+func (t TopographicMap) TrailPlots() []util.Point {
+	reachesPeak := make(map[Plot]bool)
+
+	var visit func(node Plot) bool
+	visit = func(node Plot) bool {
+		if reaches, ok := reachesPeak[node]; ok {
+			return reaches
+		}
+		reaches := node.Height == 9
+		for neighbor, weight := range t.TrailEdges[node] {
+			if weight > 0 && visit(neighbor) {
+				reaches = true
+			}
+		}
+		reachesPeak[node] = reaches
+		return reaches
+	}
+
+	for _, trailHead := range t.TrailHeads {
+		visit(trailHead)
+	}
+
+	plots := make([]util.Point, 0)
+	for node, reaches := range reachesPeak {
+		if reaches {
+			plots = append(plots, util.Point{X: node.X, Y: node.Y})
+		}
+	}
+	return plots
+}
+
 /* ----------------------------- Helper Methods ----------------------------- */
 
 // NewTopographicMap creates a new TopographicMap from a grid of integers.
 func NewTopographicMap(grid [][]int) TopographicMap {
-	bounds := len(grid)
+	bounds := util.Point{X: len(grid[0]), Y: len(grid)}
 	trailheads := make([]Plot, 0)
 	edges := make(map[Plot]map[Plot]int)
 
@@ -126,14 +189,14 @@ func NewTopographicMap(grid [][]int) TopographicMap {
 
 // getEdges returns the adjacent nodes of a given node that are one unit taller.
 func getEdges(node Plot, grid [][]int) map[Plot]int {
-	bounds := len(grid)
+	width, height := len(grid[0]), len(grid)
 	edges := make(map[Plot]int)
 
 	for i := -1; i <= 1; i += 2 {
-		if node.X+i >= 0 && node.X+i < bounds && grid[node.Y][node.X+i] == node.Height+1 {
+		if node.X+i >= 0 && node.X+i < width && grid[node.Y][node.X+i] == node.Height+1 {
 			edges[Plot{node.X + i, node.Y, grid[node.Y][node.X+i]}] = 1
 		}
-		if node.Y+i >= 0 && node.Y+i < bounds && grid[node.Y+i][node.X] == node.Height+1 {
+		if node.Y+i >= 0 && node.Y+i < height && grid[node.Y+i][node.X] == node.Height+1 {
 			edges[Plot{node.X, node.Y + i, grid[node.Y+i][node.X]}] = 1
 		}
 	}
@@ -142,15 +205,22 @@ func getEdges(node Plot, grid [][]int) map[Plot]int {
 }
 
 // parseGrid parses the input string representing a grid of integers.
-func parseGrid(data string) [][]int {
+// An error is returned if the rows differ in width, or a height is not a single digit.
+func parseGrid(data string) ([][]int, error) {
+	rows, err := util.RequireGrid(data)
+	if err != nil {
+		return nil, err
+	}
 	grid := make([][]int, 0)
-	rows := strings.Split(data, "\n")
-	for _, row := range rows {
+	for y, row := range rows {
 		current := make([]int, 0)
-		for _, col := range row {
-			current = append(current, util.AtoI(string(col)))
+		for x, col := range row {
+			if col < '0' || col > '9' {
+				return nil, util.InputError{Line: y + 1, Column: x + 1, Message: fmt.Sprintf("invalid height %q", col)}
+			}
+			current = append(current, int(col-'0'))
 		}
 		grid = append(grid, current)
 	}
-	return grid
+	return grid, nil
 }
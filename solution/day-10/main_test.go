@@ -26,3 +26,21 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Every trailhead is at height 0, and every trail stays on the map.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		grid, err := parseGrid(input)
+		if err != nil {
+			return
+		}
+		trailMap := NewTopographicMap(grid)
+		for _, trailHead := range trailMap.TrailHeads {
+			assert.Equal(t, 0, grid[trailHead.Y][trailHead.X])
+		}
+		for _, p := range trailMap.TrailPlots() {
+			assert.True(t, p.X >= 0 && p.X < len(grid[0]) && p.Y >= 0 && p.Y < len(grid), "trail leaves the map at %v", p)
+		}
+	})
+}
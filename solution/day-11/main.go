@@ -4,40 +4,85 @@
 package day11
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/memo"
+	"shaneholland.dev/aoc-2024/util/params"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle using the blink counts from the context's parameters, tracking its caches
+// in the context's memo.Registry. The number of stones grows exponentially, so a count which overflows is
+// reported as an error.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	p := params.FromContext(ctx, d.Params())
+	return part1(ctx, input, p.Int("blinks1")), part2(ctx, input, p.Int("blinks2"))
+}
+
+// The number of times the stones are blinked at in each part.
+func (d Puzzle) Params() []params.Param {
+	return []params.Param{
+		{Name: "blinks1", Kind: params.Int, Default: "25", Usage: "Blinks in part 1."},
+		{Name: "blinks2", Kind: params.Int, Default: "75", Usage: "Blinks in part 2."},
+	}
+}
+
+// The stones are a single line of numbers, so any stray whitespace is removed.
+func (d Puzzle) WhitespacePolicy() util.WhitespacePolicy {
+	return util.TrimWhitespace
 }
 
 
 // Part 1: Count the number of new stones after 25 blinks.
-func part1(input string) string {
-	stoneGraph := NewStoneGraph(input)
-	return fmt.Sprintf("%d", stoneGraph.Blink(25))
+func part1(ctx context.Context, input string, blinks int) answer.Answer {
+	stoneGraph, err := NewStoneGraph(input, memo.FromContext(ctx))
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return stoneGraph.Count(blinks)
 }
 
 // Part 2: Count the number of new stones after 75 blinks.
-func part2(input string) string {
-	stoneGraph := NewStoneGraph(input)
-	return fmt.Sprintf("%d", stoneGraph.Blink(75))
+func part2(ctx context.Context, input string, blinks int) answer.Answer {
+	stoneGraph, err := NewStoneGraph(input, memo.FromContext(ctx))
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return stoneGraph.Count(blinks)
 }
 
 /* -------------------- StoneGraph Definition and Methods ------------------- */
 
 /**
  * Represents a directional graph of stones (vertices) and resulting stones from a single blink (edges).
+ * Transitions caches the edges of each stone, and Counts caches the number of stones a single
+ * stone becomes after a given number of blinks.
+ * Overflowed is set if a count becomes too large for an int, after which counts are meaningless.
  */
 type StoneGraph struct {
-	Stones string
-	Graph map[string][]string
+	Stones      []int
+	Transitions *memo.Cache[int, []int]
+	Counts      *memo.Cache[StoneBlinks, int]
+	Overflowed  bool
+}
+
+/**
+ * A stone and a number of blinks, used as the key for cached stone counts.
+ */
+type StoneBlinks struct {
+	Stone  int
+	Blinks int
 }
 
 /**
@@ -47,13 +92,25 @@ type StoneGraph struct {
 func (g *StoneGraph) Blink(times int) int{
 	count := 0
 
-	for _, stone := range strings.Split(g.Stones, " ") {
-		count += g.EdgesAfterSteps(stone, times)
+	for _, stone := range g.Stones {
+		count = g.add(count, g.EdgesAfterSteps(stone, times))
 	}
 
 	return count
 }
 
+/**
+ * Returns the number of stones after the given number of blinks as an answer,
+ * or an error if the count overflowed.
+ */
+func (g *StoneGraph) Count(times int) answer.Answer {
+	count := g.Blink(times)
+	if g.Overflowed {
+		return answer.FromError(answer.ErrOverflow)
+	}
+	return answer.FromInt(count)
+}
+
 /**
  * Retrieves the edges for a given node, or generates them if they don't exist.
  * Rules:
@@ -67,54 +124,90 @@ func (g *StoneGraph) Blink(times int) int{
  * 	- If none of the other rules apply, the stone is replaced by a new stone; 
  *		the old stone's number multiplied by 2024 is engraved on the new stone.
  */
-func (g *StoneGraph) getEdges(node string) []string {
-	if edges, ok := g.Graph[node]; ok {
-		// Edges exist, return them
-		return edges
-	} 
-	// Generate the edges
-	edges := []string{}
-	if len(node) % 2 == 0 {
-	   mid := len(node) / 2
-	   edges = append(edges, node[:mid], strconv.Itoa(util.AtoI(node[mid:])))
-   	} else {
-	   edges = append(edges, strconv.Itoa(util.AtoI(node) * 2024))
-   	}
-	return edges
+func (g *StoneGraph) getEdges(node int) []int {
+	return g.Transitions.GetOrCompute(node, func() []int {
+		if node == 0 {
+			return []int{1}
+		}
+		digits := strconv.Itoa(node)
+		if len(digits) % 2 == 0 {
+			mid := len(digits) / 2
+			return []int{util.AtoI(digits[:mid]), util.AtoI(digits[mid:])}
+		}
+		return []int{node * 2024}
+	})
 }
 
 /**
  * Returns the number of stones which will exist after a given number of blinks, 
  * starting from a given stone.
  */
-func (g *StoneGraph) EdgesAfterSteps(start string, blinks int) int {
-	next := map[string]int{start: 1}
-	for i := 0; i < blinks; i++ {
-		queue := next
-		next = make(map[string]int)
-		for node, count := range queue {
-			for _, edge := range g.getEdges(node) {
-				if _, ok := next[edge]; !ok {
-					next[edge] = count
-				} else {
-					next[edge] += count
-				}
-			}
-		}
+func (g *StoneGraph) EdgesAfterSteps(start int, blinks int) int {
+	if blinks == 0 {
+		return 1
+	}
+
+	key := StoneBlinks{Stone: start, Blinks: blinks}
+	if count, ok := g.Counts.Get(key); ok {
+		return count
 	}
 
 	stoneCount := 0
-	for _, count := range next {
-		stoneCount += count
+	for _, edge := range g.getEdges(start) {
+		stoneCount = g.add(stoneCount, g.EdgesAfterSteps(edge, blinks-1))
 	}
+	g.Counts.Put(key, stoneCount)
 	return stoneCount
 }
 
+/**
+ * Returns a + b, or marks the graph as overflowed if the sum does not fit in an int.
+ */
+func (g *StoneGraph) add(a, b int) int {
+	sum, ok := answer.Add(int64(a), int64(b))
+	g.Overflowed = g.Overflowed || !ok
+	return int(sum)
+}
+
 /* ----------------------------- Helper Methods ----------------------------- */
 
+// The maximum number of stone transitions to keep cached.
+const TRANSITION_CACHE_SIZE = 10000
+
 /**
- * Creates a new StoneGraph from the given input.
+ * Creates a new StoneGraph from the given input, or returns an error if the stones are not valid.
+ * The graph's caches are tracked in the registry, if there is one, so that the runner can report their statistics.
  */
- func NewStoneGraph(input string) StoneGraph {
-	return StoneGraph{Stones: input, Graph: map[string][]string{"0": {"1"}}}
-}
\ No newline at end of file
+ func NewStoneGraph(input string, registry *memo.Registry) (StoneGraph, error) {
+	stones, err := parseStones(input)
+	if err != nil {
+		return StoneGraph{}, err
+	}
+	graph := StoneGraph{
+		Stones:      stones,
+		Transitions: memo.NewCache[int, []int](TRANSITION_CACHE_SIZE),
+		Counts:      memo.NewCache[StoneBlinks, int](0),
+	}
+	registry.Track("day-11/transitions", graph.Transitions)
+	registry.Track("day-11/counts", graph.Counts)
+	return graph, nil
+}
+
+/**
+ * Returns the numbers engraved on the stones, which are separated by spaces.
+ * The rules only apply to numbers without a sign, so anything else is an error.
+ */
+func parseStones(input string) ([]int, error) {
+	stones := make([]int, 0)
+	for _, field := range strings.Fields(input) {
+		if strings.Trim(field, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid stone %q, expected a number", field)
+		}
+		stone, err := util.ParseInt(field)
+		if err != nil {
+			return nil, err
+		}
+		stones = append(stones, stone)
+	}
+	return stones, nil
+}
@@ -1,10 +1,15 @@
 package day11
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/params"
 )
 
 const PART_1_EXPECTED = "55312"
@@ -26,3 +31,30 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+func TestOverflow(t *testing.T) {
+	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
+	values, err := params.New(Puzzle{}.Params(), map[string]string{"blinks2": "250"})
+	assert.NoError(t, err)
+	solver := Puzzle{}
+	answer1, answer2 := solver.SolveAnswers(params.WithValues(context.Background(), values), testInput)
+
+	assert.Equal(t, PART_1_EXPECTED, answer1.String())
+	assert.Equal(t, answer.Error, answer2.Kind)
+	assert.ErrorIs(t, answer2.Err(), answer.ErrOverflow)
+}
+
+// Writing the parsed stones back out as input gives the same stones.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		stones, err := parseStones(input)
+		if err != nil {
+			return
+		}
+
+		reparsed, err := parseStones(strings.Trim(fmt.Sprint(stones), "[]"))
+		assert.NoError(t, err)
+		assert.Equal(t, stones, reparsed)
+	})
+}
@@ -0,0 +1,33 @@
+package day12
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+/* -------------------------------- Generator ------------------------------- */
+
+// Generate returns a random garden of size by size plots. Most plots grow the same plant as the plot
+// to their left or above, so the garden is made of irregular regions of many shapes and sizes.
+func (d Puzzle) Generate(rng *rand.Rand, size int) (string, map[string]string) {
+	rows := make([][]byte, size)
+	for y := range rows {
+		rows[y] = make([]byte, size)
+		for x := range rows[y] {
+			switch chance := rng.IntN(10); {
+			case chance < 4 && x > 0:
+				rows[y][x] = rows[y][x-1]
+			case chance < 8 && y > 0:
+				rows[y][x] = rows[y-1][x]
+			default:
+				rows[y][x] = byte('A' + rng.IntN(26))
+			}
+		}
+	}
+
+	garden := make([]string, size)
+	for y, row := range rows {
+		garden[y] = string(row)
+	}
+	return strings.Join(garden, "\n"), nil
+}
@@ -4,30 +4,65 @@
 package day12
 
 import (
-	"fmt"
+	"context"
 	"slices"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/dsu"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
 
+// Visualize draws the garden with each region in its own color.
+func (d Puzzle) Visualize(_ context.Context, input string) (*render.Grid, error) {
+	garden, err := parseGarden(input)
+	if err != nil {
+		return nil, err
+	}
+	lines := util.GetLines(input)
+
+	regionIndex := make(map[util.Point]int)
+	for i, region := range garden.Regions {
+		for _, plot := range region.Plots {
+			regionIndex[plot] = i
+		}
+	}
+
+	return render.NewGrid(len(lines[0]), len(lines), func(p util.Point) render.Style {
+		return render.Style{Char: rune(lines[p.Y][p.X]), Color: render.Palette(regionIndex[p])}
+	}), nil
+}
+
 // Part 1: Return the cost of fencing in the garden. (Perimeter * Area)
-func part1(input string) string {
-	garden := parseGarden(input)
-	return fmt.Sprintf("%d", garden.GetFencingCost(false))
+func part1(input string) answer.Answer {
+	garden, err := parseGarden(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(garden.GetFencingCost(false))
 }
 
 
 // Part 2: Return the cost of fencing in the garden with a bulk discount. (Sides * Area) 
-func part2(input string) string {
-	garden := parseGarden(input)
-	return fmt.Sprintf("%d", garden.GetFencingCost(true))
+func part2(input string) answer.Answer {
+	garden, err := parseGarden(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(garden.GetFencingCost(true))
 }
 
 /* ---------------------- Garden Definition and Methods --------------------- */
@@ -142,32 +177,36 @@ func (r Region) countInteriorCorners(node util.Point) int {
 	return corners
 }
 
-// GetSubRegions returns a list of sub-regions within the current region.
+// GetComponentRegions returns a list of sub-regions within the current region.
 // Each sub-region is a connected component of plots within the region.
 func (r Region) GetComponentRegions() []Region {
-	subRegions := []Region{}
-	untested := slices.Clone(r.Plots)
-
-	for len(untested) > 0 {
-		region := Region{Plots: []util.Point{}, Graph: map[util.Point][]util.Point{}}
-		queue := []util.Point{untested[0]}
-		untested = untested[1:]
-
-		for len(queue) > 0 {
-			plot := queue[0]
-			queue = queue[1:]
-			region.Plots = append(region.Plots, plot)
-			region.Graph[plot] = r.Graph[plot]
+	index := make(map[util.Point]int, len(r.Plots))
+	for i, plot := range r.Plots {
+		index[plot] = i
+	}
 
-			for _, edge := range r.Graph[plot] {
-				if index := slices.Index(untested, edge); index >= 0 {
-					queue = append(queue, edge)
-					untested = append(untested[:index], untested[index+1:]...)
-				}
+	// Union each plot with the plots it shares an edge with
+	components := dsu.New(len(r.Plots))
+	for i, plot := range r.Plots {
+		for _, edge := range r.Graph[plot] {
+			if j, ok := index[edge]; ok {
+				components.Union(i, j)
 			}
 		}
+	}
 
-		subRegions = append(subRegions, region)
+	// Group the plots by the component they belong to
+	subRegions := []Region{}
+	subRegionIndex := make(map[int]int)
+	for i, plot := range r.Plots {
+		root := components.Find(i)
+		if _, ok := subRegionIndex[root]; !ok {
+			subRegionIndex[root] = len(subRegions)
+			subRegions = append(subRegions, Region{Plots: []util.Point{}, Graph: map[util.Point][]util.Point{}})
+		}
+		subRegion := &subRegions[subRegionIndex[root]]
+		subRegion.Plots = append(subRegion.Plots, plot)
+		subRegion.Graph[plot] = r.Graph[plot]
 	}
 
 	return subRegions
@@ -177,14 +216,17 @@ func (r Region) GetComponentRegions() []Region {
 
 // parseGarden parses the input string representing a garden and returns a Garden struct.
 // Each character in the input represents a plot, and adjacent plots with the same character
-// are considered connected.
-func parseGarden(input string) Garden {
-    lines := util.GetLines(input)
-    bounds := len(lines)
+// are considered connected. An error is returned if the rows of the garden differ in width.
+func parseGarden(input string) (Garden, error) {
+    lines, err := util.RequireGrid(input)
+    if err != nil {
+        return Garden{}, err
+    }
+    width, height := len(lines[0]), len(lines)
 
     // inBounds checks if a point is within the garden bounds.
     inBounds := func(p util.Point) bool {
-        return p.X >= 0 && p.X < bounds && p.Y >= 0 && p.Y < bounds
+        return p.X >= 0 && p.X < width && p.Y >= 0 && p.Y < height
     }
 
     // getEdges returns the adjacent points of a given point that have the same character.
@@ -199,28 +241,23 @@ func parseGarden(input string) Garden {
         return edges
     }
 
-    regionMap := make(map[string]Region)
+	// Each connected group of plots with the same character is its own region.
+	labels, count := dsu.LabelGrid(width, height, func(a, b util.Point) bool {
+		return lines[a.Y][a.X] == lines[b.Y][b.X]
+	})
 
-    for y, line := range lines {
-        for x, char := range line {
-            region := string(char)
-            plot := util.Point{X: x, Y: y}
-            if _, ok := regionMap[region]; !ok {
-                regionMap[region] = Region{Plots: []util.Point{}, Graph: map[util.Point][]util.Point{}}
-            }
-            r := regionMap[region]
-            r.Plots = append(r.Plots, plot)
-            r.Graph[plot] = getEdges(plot)
-            regionMap[region] = r
-        }
-    }
-
-	// There may be multiple regions of the same type in the Garden.
-	// Break them into their connected components.
-	regions := []Region{}
-	for _, region := range regionMap {
-		regions = append(regions, region.GetComponentRegions()...)
+	regions := make([]Region, count)
+	for i := range regions {
+		regions[i] = Region{Plots: []util.Point{}, Graph: map[util.Point][]util.Point{}}
+	}
+	for y, line := range lines {
+		for x := 0; x < len(line); x++ {
+			plot := util.Point{X: x, Y: y}
+			region := &regions[labels[y][x]]
+			region.Plots = append(region.Plots, plot)
+			region.Graph[plot] = getEdges(plot)
+		}
 	}
 
-    return Garden{Regions: regions}
+	return Garden{Regions: regions}, nil
 }
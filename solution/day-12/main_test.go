@@ -26,3 +26,25 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Every plot of the garden belongs to exactly one region, of a single type of plant.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		garden, err := parseGarden(input)
+		if err != nil {
+			return
+		}
+		lines := util.GetLines(input)
+		plots := 0
+		for _, region := range garden.Regions {
+			plant := lines[region.Plots[0].Y][region.Plots[0].X]
+			for _, p := range region.Plots {
+				assert.Equal(t, plant, lines[p.Y][p.X])
+			}
+			plots += len(region.Plots)
+		}
+
+		assert.Equal(t, len(lines)*len(lines[0]), plots)
+	})
+}
@@ -4,44 +4,67 @@
 package day13
 
 import (
+	"context"
 	"fmt"
-	"regexp"
-	"strings"
+	"math"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/parse"
 )
 
 type Puzzle struct{}
 
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle using the press limit and prize offset from the context's parameters.
+// A large offset makes the costs very large, so a cost which overflows is reported as an error.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	p := params.FromContext(ctx, d.Params())
+	return part1(ctx, input, p.Int("press-limit")), part2(ctx, input, p.Int("offset"))
+}
+
+// The press limit of part 1, and the distance the prizes are moved in part 2.
+func (d Puzzle) Params() []params.Param {
+	return []params.Param{
+		{Name: "press-limit", Kind: params.Int, Default: "100", Usage: "The most times each button may be pressed in part 1."},
+		{Name: "offset", Kind: params.Int, Default: "10000000000000", Usage: "The distance each prize is moved in part 2."},
+	}
 }
 
 // Part 1: Return the minimum cost to win the prize.
 // Limit each button press to 100.
-func part1(input string) string {
-	clawMachines := parseClawMachines(input)
-	cost := 0
-	for _, clawMachine := range clawMachines {
-		cost += clawMachine.MinimumCost(100)
+func part1(ctx context.Context, input string, pressLimit int) answer.Answer {
+	clawMachines, err := parseClawMachines(input)
+	if err != nil {
+		return answer.FromError(err)
 	}
-	
-	return fmt.Sprintf("%d", cost)
+	return totalCost(ctx, clawMachines, pressLimit)
 }
 
 // Part 2: Return the minimum cost to win the prize
 // with the prize coordinates increased by 10000000000000.
 // There is no limit to the number of button presses.
-func part2(input string) string {
-	clawMachines := parseClawMachines(input)
-	cost := 0
-	for _, clawMachine := range clawMachines {
-		clawMachine.Prize.X += 10000000000000
-		clawMachine.Prize.Y += 10000000000000
-		cost += clawMachine.MinimumCost(-1)
+func part2(ctx context.Context, input string, offset int) answer.Answer {
+	clawMachines, err := parseClawMachines(input)
+	if err != nil {
+		return answer.FromError(err)
 	}
-	
-	return fmt.Sprintf("%d", cost)
+	for i := range clawMachines {
+		x, okX := answer.Add(int64(clawMachines[i].Prize.X), int64(offset))
+		y, okY := answer.Add(int64(clawMachines[i].Prize.Y), int64(offset))
+		if !okX || !okY {
+			return answer.FromError(answer.ErrOverflow)
+		}
+		clawMachines[i].Prize = util.Point{X: int(x), Y: int(y)}
+	}
+
+	return totalCost(ctx, clawMachines, -1)
 }
 
 /* ---------------------- Button Definition and Methods --------------------- */
@@ -60,21 +83,46 @@ type ClawMachine struct {
 }
 
 // Return the minimum cost to win the prize, limiting each button press to the pressLimit.
-func (cm ClawMachine) MinimumCost(pressLimit int) int {
+// ok is false if the cost, or the numbers used to find it, are too large for an int.
+func (cm ClawMachine) MinimumCost(pressLimit int) (cost int, ok bool) {
+	if cm.overflows() {
+		return 0, false
+	}
+	// Buttons which move the claw in the same direction have no single solution, so the prize is treated as unwinnable
+	if cm.determinant() == 0 {
+		return 0, true
+	}
 	aPresses, bPresses := cm.PressesToWin()
+	if math.Abs(aPresses) >= math.MaxInt64 || math.Abs(bPresses) >= math.MaxInt64 {
+		return 0, false
+	}
 
 	// Check to see if the press numbers are whole numbers
 	// If they are not, then the prize cannot be won.
 	if aPresses != float64(int(aPresses)) || bPresses != float64(int(bPresses)) {
-		return 0
+		return 0, true
 	}
 
 	aButtonPresses := int(aPresses)
 	bButtonPresses := int(bPresses)
 	if (pressLimit == -1 || (aButtonPresses <= pressLimit && bButtonPresses <= pressLimit)) {
-		return aButtonPresses * cm.ButtonA.Cost + bButtonPresses * cm.ButtonB.Cost
+		aCost, okA := answer.Mul(int64(aButtonPresses), int64(cm.ButtonA.Cost))
+		bCost, okB := answer.Mul(int64(bButtonPresses), int64(cm.ButtonB.Cost))
+		total, okTotal := answer.Add(aCost, bCost)
+		return int(total), okA && okB && okTotal
+	}
+	return 0, true
+}
+
+// Returns true if any of the products used by PressesToWin overflow an int.
+func (cm ClawMachine) overflows() bool {
+	a, b, prize := cm.ButtonA.Action, cm.ButtonB.Action, cm.Prize
+	for _, pair := range [][2]int{{prize.Y, a.X}, {a.Y, prize.X}, {prize.X, b.Y}, {prize.Y, b.X}, {a.X, b.Y}, {a.Y, b.X}} {
+		if _, ok := answer.Mul(int64(pair[0]), int64(pair[1])); !ok {
+			return true
+		}
 	}
-	return 0
+	return false
 }
 
 // Return the number of button presses required to win the prize.
@@ -97,46 +145,76 @@ func (cm ClawMachine) PressesToWin() (buttonA, buttonB float64) {
 	//	Button A presses: 80
 	//  Button B presses: 40
 
-	// Solve for Button B first
-	buttonB = float64((cm.Prize.Y * cm.ButtonA.Action.X) - (cm.ButtonA.Action.Y * cm.Prize.X))
-	buttonB = buttonB / float64(cm.ButtonA.Action.X * cm.ButtonB.Action.Y - cm.ButtonA.Action.Y * cm.ButtonB.Action.X)
-
-	// Use Button B to solve for Button A
-	buttonA = (float64(cm.Prize.X) - (float64(cm.ButtonB.Action.X) * buttonB)) / float64(cm.ButtonA.Action.X)
+	// Solve for both buttons by Cramer's rule, so neither divides by a button which does not move in X
+	determinant := float64(cm.determinant())
+	buttonA = float64((cm.Prize.X * cm.ButtonB.Action.Y) - (cm.Prize.Y * cm.ButtonB.Action.X)) / determinant
+	buttonB = float64((cm.Prize.Y * cm.ButtonA.Action.X) - (cm.ButtonA.Action.Y * cm.Prize.X)) / determinant
 
 	return buttonA, buttonB
 }
+
+// Returns the determinant of the buttons' movements, which is zero if they move the claw in the same direction.
+func (cm ClawMachine) determinant() int {
+	return cm.ButtonA.Action.X * cm.ButtonB.Action.Y - cm.ButtonA.Action.Y * cm.ButtonB.Action.X
+}
 /* ---------------------------- Helper Functions ---------------------------- */
 
+// Returns the total cost to win every prize which can be won, or an error if it overflows.
+// The cost of each claw machine is found in parallel, on the number of workers allowed by the context.
+func totalCost(ctx context.Context, clawMachines []ClawMachine, pressLimit int) answer.Answer {
+	type result struct {
+		cost int
+		ok   bool
+	}
+	results := parallel.Map(ctx, clawMachines, func(clawMachine ClawMachine) result {
+		cost, ok := clawMachine.MinimumCost(pressLimit)
+		return result{cost, ok}
+	})
+
+	total := answer.Sum{}
+	for _, result := range results {
+		if !result.ok {
+			return answer.FromError(answer.ErrOverflow)
+		}
+		total.Add(int64(result.cost))
+	}
+	return total.Answer()
+}
+
 // Parses all claw machines from the input.
-func parseClawMachines(input string) []ClawMachine {
+func parseClawMachines(input string) ([]ClawMachine, error) {
 	clawMachines := []ClawMachine{}
-	for _, lines := range strings.Split(input, "\n\n") {
-		clawMachines = append(clawMachines, parseClawMachine(lines))
+	for i, lines := range parse.Sections(input) {
+		clawMachine, err := parseClawMachine(lines)
+		if err != nil {
+			return nil, fmt.Errorf("claw machine %d: %w", i+1, err)
+		}
+		clawMachines = append(clawMachines, clawMachine)
 	}
-	return clawMachines
+	return clawMachines, nil
 }
 
 // Parses a single claw machine from the input.
-func parseClawMachine(input string) ClawMachine {
-	// This pattern should match both the button and prize coordinates.
-	pattern := `.*: X.(\d+), Y.(\d+)`
-
-	re := regexp.MustCompile(pattern)
+// An error is returned unless there are three lines, for button A, button B and the prize.
+func parseClawMachine(input string) (ClawMachine, error) {
 	lines := util.GetLines(input)
+	if len(lines) != 3 {
+		return ClawMachine{}, fmt.Errorf("expected 3 lines, found %d", len(lines))
+	}
 
+	// Each line holds an X and Y value for either a button or the prize.
 	coords := []util.Point{}
 	for _, line := range lines {
-		match := re.FindStringSubmatch(line)
-		x := util.AtoI(match[1])
-		y := util.AtoI(match[2])
-		coords = append(coords, util.Point{X: x, Y: y})
+		values := parse.Ints(line)
+		if len(values) != 2 {
+			return ClawMachine{}, fmt.Errorf("expected an X and Y value in %q", line)
+		}
+		coords = append(coords, util.Point{X: values[0], Y: values[1]})
 	}
 
 	return ClawMachine{
 		ButtonA: Button{Action: coords[0], Cost: 3},
 		ButtonB: Button{Action: coords[1], Cost: 1},
 		Prize: coords[2],
-	}
+	}, nil
 }
-
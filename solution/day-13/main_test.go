@@ -1,10 +1,15 @@
 package day13
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/params"
 )
 
 const PART_1_EXPECTED = "480"
@@ -26,3 +31,44 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+func TestOverflow(t *testing.T) {
+	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
+	values, err := params.New(Puzzle{}.Params(), map[string]string{"offset": "9000000000000000000"})
+	assert.NoError(t, err)
+	solver := Puzzle{}
+	_, answer2 := solver.SolveAnswers(params.WithValues(context.Background(), values), testInput)
+
+	assert.ErrorIs(t, answer2.Err(), answer.ErrOverflow)
+}
+
+// Buttons which move in the same direction cannot win their prize, and a button which does not move in X
+// does not stop the other machines being won.
+func TestDegenerateButtons(t *testing.T) {
+	solver := Puzzle{}
+	answer1, answer2 := solver.Solve("Button A: X+2, Y+2\nButton B: X+1, Y+1\nPrize: X=5, Y=7\n\n" +
+		"Button A: X+0, Y+1\nButton B: X+1, Y+0\nPrize: X=3, Y=4\n")
+
+	assert.Equal(t, "15", answer1)
+	assert.Equal(t, "40000000000015", answer2)
+}
+
+// Writing the parsed claw machines back out as input gives the same claw machines.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		clawMachines, err := parseClawMachines(input)
+		if err != nil {
+			return
+		}
+		sections := make([]string, len(clawMachines))
+		for i, m := range clawMachines {
+			sections[i] = fmt.Sprintf("Button A: X%+d, Y%+d\nButton B: X%+d, Y%+d\nPrize: X=%d, Y=%d",
+				m.ButtonA.Action.X, m.ButtonA.Action.Y, m.ButtonB.Action.X, m.ButtonB.Action.Y, m.Prize.X, m.Prize.Y)
+		}
+
+		reparsed, err := parseClawMachines(strings.Join(sections, "\n\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, clawMachines, reparsed)
+	})
+}
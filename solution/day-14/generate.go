@@ -0,0 +1,22 @@
+package day14
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+/* -------------------------------- Generator ------------------------------- */
+
+// Generate returns a list of size robots, at random positions in a lobby of the real puzzle's size,
+// each moving at a random velocity.
+func (d Puzzle) Generate(rng *rand.Rand, size int) (string, map[string]string) {
+	const width, height = 101, 103
+
+	robots := make([]string, size)
+	for i := range robots {
+		robots[i] = fmt.Sprintf("p=%d,%d v=%d,%d",
+			rng.IntN(width), rng.IntN(height), rng.IntN(2*width-1)-width+1, rng.IntN(2*height-1)-height+1)
+	}
+	return strings.Join(robots, "\n"), map[string]string{"bounds": fmt.Sprintf("%dx%d", width, height)}
+}
@@ -4,12 +4,17 @@
 package day14
 
 import (
+	"context"
 	"fmt"
 	"math"
-	"regexp"
-	"slices"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parallel"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/parse"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 /* ------------------------------- Main Method ------------------------------ */
@@ -17,20 +22,55 @@ type Puzzle struct{}
 
 // The Solve method is called to solve the puzzle.
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle using the lobby size from the context's parameters,
+// reporting the progress of part 2 to the context's progress.Reporter.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	p := params.FromContext(ctx, d.Params())
+	return part1(input, p.Size("bounds"), p.Int("seconds")), part2(ctx, input, p.Size("bounds"))
+}
+
+// The size of the lobby, which is smaller for the example input, and the time waited in part 1.
+func (d Puzzle) Params() []params.Param {
+	return []params.Param{
+		{Name: "bounds", Kind: params.Size, Default: "101x103", Usage: "The width and height of the lobby."},
+		{Name: "seconds", Kind: params.Int, Default: "100", Usage: "The seconds waited before the safety factor is found in part 1."},
+	}
+}
+
+// Animate records the robots moving around the lobby, one frame per second, until the context is cancelled.
+func (d Puzzle) Animate(ctx context.Context, input string, recorder *render.Recorder) error {
+	lobby, err := parseLobby(input, params.FromContext(ctx, d.Params()).Size("bounds"))
+	if err != nil {
+		return err
+	}
+	for i := 0; i <= lobby.Bounds.X*lobby.Bounds.Y && !recorder.Full(); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lobby.Update(i)
+		recorder.Record(lobby.Render)
+	}
+	return nil
 }
 
 /* -------------------------------- Solution -------------------------------- */
 
 // Part 1: Calculate the Safety Factor of the lobby after 100 seconds. 
-func part1(input string) string {
-	lobby := parseLobby(input)
-	lobby.Update(100)
-	return fmt.Sprintf("%d", lobby.SafetyFactor())
+func part1(input string, bounds util.Point, seconds int) answer.Answer {
+	lobby, err := parseLobby(input, bounds)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	lobby.Update(seconds)
+	return answer.FromInt(lobby.SafetyFactor())
 }
 
 // Part 2: Determine the number of seconds it takes for the robots to form a Christmas tree.
-func part2(input string) string {
+func part2(ctx context.Context, input string, bounds util.Point) answer.Answer {
 	// After watching for a pattern, we noticed that, starting at 97 seconds, 
 	// a vertical formation appears every 101 seconds.  We updated our script to draw the lobby
 	// every 101 seconds starting at 97 seconds.  After watching that for a while we 
@@ -41,20 +81,31 @@ func part2(input string) string {
 
 	// Another way it seems that we can solve this is by looking for minimum safety factor.
 	// At the point where the Tree appears, the safety factor is at its minimum.
-	lobby := parseLobby(input)
+
+	// Each second is independent, so they are watched in parallel, with a copy of the lobby per worker.
+	lobby, err := parseLobby(input, bounds)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	reporter := progress.FromContext(ctx)
+	reporter.Start("watching robots", lobby.Bounds.X*lobby.Bounds.Y+1)
+
+	newLobby := func() *Lobby { return &Lobby{Robots: lobby.Robots, Bounds: lobby.Bounds} }
+	safetyFactors := parallel.MapState(ctx, lobby.Bounds.X*lobby.Bounds.Y+1, newLobby, func(l *Lobby, seconds int) int {
+		l.Update(seconds)
+		reporter.Add(1)
+		return l.SafetyFactor()
+	})
 
 	minSafetyFactor := math.Inf(1)
 	secondsAtMinimumSafetyFactor := 0
-	for i:=0; i <= lobby.Bounds.X * lobby.Bounds.Y; i++ {
-		lobby.Update(i)
-		safetyFactor := lobby.SafetyFactor()
-
+	for i, safetyFactor := range safetyFactors {
 		if safetyFactor < int(minSafetyFactor) {
 			minSafetyFactor = float64(safetyFactor)
 			secondsAtMinimumSafetyFactor = i
 		}
 	}
-	return fmt.Sprintf("%d", secondsAtMinimumSafetyFactor)
+	return answer.FromInt(secondsAtMinimumSafetyFactor)
 }
 
 /* ---------------------- Lobby Definition and Methods ---------------------- */
@@ -110,19 +161,15 @@ func (l Lobby) getWrappedPosition(pos util.Point) util.Point{
 	return util.Point{X: x, Y: y}
 }
 
+// Returns the lobby as a string, where robots are drawn as '#'.
 func (l Lobby) ToString() string {
-	output := ""
-	for y:=0; y<l.Bounds.Y; y++ {
-		for x:=0; x<l.Bounds.X; x++ {
-			if slices.Contains(l.Positions, util.Point{X: x, Y: y}) {
-				output += "#"
-			} else {
-				output += "."
-			}
-		}
-		output += "\n"
-	}
-	return output
+	return l.Render().ASCII()
+}
+
+// Returns a grid of the lobby, where robots are drawn as '#'.
+func (l Lobby) Render() *render.Grid {
+	robot := render.Style{Char: '#', Color: render.Green, Name: "robot"}
+	return render.FromPoints(l.Positions, l.Bounds, robot, render.Style{Char: '.'})
 }
 
 /* ---------------------- Robot Definition and Methods --------------------- */
@@ -142,29 +189,28 @@ func (robot Robot) move(seconds int) util.Point {
 
 /* ---------------------------- Helper Functions ---------------------------- */
 
-// Define the bounds of the lobby, which is different for the test input.
-var TEST_BOUNDS = util.Point{X: 11, Y: 7}
-var BOUNDS = util.Point{X: 101, Y: 103}
-
-// Parse the input string into a Lobby containing robots moving in straight lines
-func parseLobby(input string) Lobby {
-
-	pattern := `p=(-?\d+),(-?\d+) v=(-?\d+),(-?\d+)`
-	re := regexp.MustCompile(pattern)
-	matches := re.FindAllStringSubmatch(input, -1)
-
-	robots := make([]Robot, len(matches))
-	positions := make([]util.Point, len(matches))
-	for i, match := range matches {
+// Parse the input string into a Lobby of the given size, containing robots moving in straight lines.
+// Every robot must start inside the lobby, so input of the wrong size is never silently solved.
+func parseLobby(input string, bounds util.Point) (Lobby, error) {
+	lines := util.NonEmptyLines(input)
+	robots := make([]Robot, len(lines))
+	positions := make([]util.Point, len(lines))
+	for i, line := range lines {
+		// Each robot is described by four integers: p=x,y v=dx,dy
+		values := parse.Ints(line.Text)
+		if len(values) != 4 {
+			return Lobby{}, util.InputError{Line: line.Number, Column: 1, Message: fmt.Sprintf("expected p=x,y v=dx,dy, found %q", line.Text)}
+		}
 		robots[i] = Robot{
-			Start: util.Point{X: util.AtoI(match[1]), Y: util.AtoI(match[2])},
-			Vector: util.Point{X: util.AtoI(match[3]), Y: util.AtoI(match[4])},
+			Start: util.Point{X: values[0], Y: values[1]},
+			Vector: util.Point{X: values[2], Y: values[3]},
 		}
 		positions[i] = robots[i].Start
+
+		if start := robots[i].Start; start.X < 0 || start.Y < 0 || start.X >= bounds.X || start.Y >= bounds.Y {
+			return Lobby{}, fmt.Errorf("robot %d starts at %d,%d, outside the %dx%d lobby. Set the bounds parameter to the size of the lobby", i+1, start.X, start.Y, bounds.X, bounds.Y)
+		}
 	}
 
-	if (len(robots) < 50) {
-		return Lobby{Robots: robots, Bounds: TEST_BOUNDS, Positions: positions}
-	} 
-	return Lobby{Robots: robots, Bounds: BOUNDS, Positions: positions}
-}
\ No newline at end of file
+	return Lobby{Robots: robots, Bounds: bounds, Positions: positions}, nil
+}
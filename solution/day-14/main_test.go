@@ -1,28 +1,68 @@
 package day14
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 const PART_1_EXPECTED = "12"
 const PART_2_EXPECTED = "0"
 const PUZZLE_INPUT_PATH = "./test-data.txt"
 
+// The example lobby is smaller than the real one.
+var TEST_PARAMS = map[string]string{"bounds": "11x7"}
+
+// Returns a context carrying the parameters of the example input.
+func testContext(t *testing.T) context.Context {
+	values, err := params.New(Puzzle{}.Params(), TEST_PARAMS)
+	assert.NoError(t, err)
+	return params.WithValues(context.Background(), values)
+}
+
 func TestPart1(t *testing.T) {
 	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
 	solver := Puzzle{}
-	answer1, _ := solver.Solve(testInput)
+	answer1, _ := solver.SolveAnswers(testContext(t), testInput)
 
-	assert.Equal(t, PART_1_EXPECTED, answer1)
+	assert.Equal(t, PART_1_EXPECTED, answer1.String())
 }
 
 func TestPart2(t *testing.T) {
 	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
 	solver := Puzzle{}
-	_, answer2 := solver.Solve(testInput)
+	_, answer2 := solver.SolveAnswers(testContext(t), testInput)
+
+	assert.Equal(t, PART_2_EXPECTED, answer2.String())
+}
+
+// The animation stops once the recorder is full, rather than simulating every second.
+func TestAnimate(t *testing.T) {
+	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
+	recorder := render.NewRecorder(1, 1)
+	recorder.MaxFrames = 3
+	assert.NoError(t, Puzzle{}.Animate(testContext(t), testInput, recorder))
+
+	assert.Equal(t, 3, recorder.Frames())
+	assert.True(t, recorder.Full())
+}
 
-	assert.Equal(t, PART_2_EXPECTED, answer2)
+// The robots never leave the lobby, however fast they move.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		bounds := util.Point{X: 11, Y: 7}
+		lobby, err := parseLobby(input, bounds)
+		if err != nil {
+			return
+		}
+		lobby.Update(100)
+		for _, p := range lobby.Positions {
+			assert.True(t, p.X >= 0 && p.X < bounds.X && p.Y >= 0 && p.Y < bounds.Y, "robot left the lobby at %v", p)
+		}
+	})
 }
@@ -4,11 +4,15 @@
 package day15
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parse"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 /* ------------------------------- Main Method ------------------------------ */
@@ -16,29 +20,56 @@ type Puzzle struct{}
 
 // The Solve method is called to solve the puzzle.
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
+// Animate records the robot pushing boxes around the wide warehouse, one frame per instruction.
+func (d Puzzle) Animate(_ context.Context, input string, recorder *render.Recorder) error {
+	warehouse, err := parseWarehouse(input)
+	if err != nil {
+		return err
+	}
+	warehouse.Expand()
+
+	recorder.Record(warehouse.Render)
+	for warehouse.NextInstruction() {
+		recorder.Record(warehouse.Render)
+	}
+	return nil
+}
+
 /* -------------------------------- Solution -------------------------------- */
 
 // Part 1: Return the sum of the GPS coordinates of all boxes in the warehouse.
-func part1(input string) string {
-	warehouse := parseWarehouse(input)
+func part1(input string) answer.Answer {
+	warehouse, err := parseWarehouse(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	for warehouse.NextInstruction() {
 		// Move boxes until out of instructions
 	}
-	return fmt.Sprintf("%d", warehouse.BoxGpsSum())
+	return answer.FromInt(warehouse.BoxGpsSum())
 }
 
 // Part 2:Return the sum of the GPS coordinates of all boxes in the wide warehouse.
-func part2(input string) string {
-	warehouse := parseWarehouse(input)
+func part2(input string) answer.Answer {
+	warehouse, err := parseWarehouse(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	warehouse.Expand()
 	for warehouse.NextInstruction() {
 		// Move boxes until out of instructions
 	}
 
-	return fmt.Sprintf("%d", warehouse.BoxGpsSum())
+	return answer.FromInt(warehouse.BoxGpsSum())
 }
 
 /* -------------------------------- Constants ------------------------------- */
@@ -112,7 +143,7 @@ func (w *Warehouse) Expand() {
 
 	// Adjust the robot's position
 	w.Robot.X *= 2
-	w.Map[w.Robot.Y][w.Robot.X*2+1] = FREE
+	w.Map[w.Robot.Y][w.Robot.X+1] = FREE
 
 	w.Expanded = true
 }
@@ -213,42 +244,52 @@ func (w *Warehouse) pushBoxes(boxStacks BoxStackStack, next util.Point) {
 
 // Draws the warehouse to the console.
 func (w Warehouse) Draw() {
-	for y := 0; y < len(w.Map); y++ {
-		for x := 0; x < len(w.Map[y]); x++ {
-			switch w.Map[y][x] {
-			case WALL:
-				print("#")
-			case BOX:
-				print("O")
-			case BOX_LEFT:
-				print("[")
-			case BOX_RIGHT:
-				print("]")
-			case FREE:
-				if w.Robot.X == x && w.Robot.Y == y {
-					print("@")
-				} else {
-					print(".")
-				}
-			}
-		}
-		println()
+	fmt.Print(w.Render().ASCII())
+}
+
+// Returns a grid of the warehouse, with the robot drawn as '@'.
+func (w Warehouse) Render() *render.Grid {
+	styles := map[int]render.Style{
+		FREE:      {Char: '.'},
+		WALL:      {Char: '#', Color: render.Gray, Name: "wall"},
+		BOX:       {Char: 'O', Color: render.Orange, Name: "box"},
+		BOX_LEFT:  {Char: '[', Color: render.Orange, Name: "box"},
+		BOX_RIGHT: {Char: ']', Color: render.Orange, Name: "box"},
 	}
+
+	grid := render.NewGrid(len(w.Map[0]), len(w.Map), func(p util.Point) render.Style {
+		return styles[w.Map[p.Y][p.X]]
+	})
+	return grid.Highlight([]util.Point{w.Robot}, render.Style{Char: '@', Color: render.Yellow, Name: "robot"})
 }
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
 // Parses the input string into a Warehouse struct.
-func parseWarehouse(input string) Warehouse {
+// An error is returned unless the map is rectangular, enclosed by walls and holds exactly one robot,
+// and every instruction is one of ^, v, < or >.
+func parseWarehouse(input string) (Warehouse, error) {
 	robot := util.Point{}
+	robots := 0
 
-	mapGrid := strings.Split(strings.Split(input, "\n\n")[0], "\n")
-	instructionsString := strings.Split(input, "\n\n")[1]
+	sections := parse.Sections(input)
+	if len(sections) != 2 {
+		return Warehouse{}, fmt.Errorf("expected a map and instructions separated by a blank line, found %d sections", len(sections))
+	}
+	mapGrid, err := util.RequireGrid(sections[0])
+	if err != nil {
+		return Warehouse{}, err
+	}
+	instructionsString := sections[1]
 	var warehouseMap [][]int = make([][]int, len(mapGrid))
 
 	for y := 0; y < len(mapGrid); y++ {
 		warehouseMap[y] = make([]int, len(mapGrid[y]))
 		for x := 0; x < len(mapGrid[y]); x++ {
+			border := x == 0 || y == 0 || x == len(mapGrid[y])-1 || y == len(mapGrid)-1
+			if border && mapGrid[y][x] != '#' {
+				return Warehouse{}, util.InputError{Line: y + 1, Column: x + 1, Message: "the warehouse must be enclosed by walls"}
+			}
 			switch string(mapGrid[y][x]) {
 			case "#":
 				warehouseMap[y][x] = WALL
@@ -258,26 +299,40 @@ func parseWarehouse(input string) Warehouse {
 				warehouseMap[y][x] = BOX
 			case "@":
 				robot = util.Point{X: x, Y: y}
+				robots++
 				warehouseMap[y][x] = FREE
+			default:
+				return Warehouse{}, util.InputError{Line: y + 1, Column: x + 1, Message: fmt.Sprintf("unexpected %q in map", mapGrid[y][x])}
 			}
 		}
 	}
+	if robots != 1 {
+		return Warehouse{}, fmt.Errorf("expected one robot (@) in the warehouse, found %d", robots)
+	}
 
+	instructions, err := parseInstructions(instructionsString)
+	if err != nil {
+		return Warehouse{}, err
+	}
 	return Warehouse{
 		Map:          warehouseMap,
 		Robot:        robot,
-		Instructions: parseInstructions(instructionsString)}
+		Instructions: instructions}, nil
 }
 
 // Parses the instructions string into an array of position deltas.
-func parseInstructions(input string) []util.Point {
+func parseInstructions(input string) ([]util.Point, error) {
 	instructions := []util.Point{}
 
 	input = strings.ReplaceAll(input, "\n", "")
 	for _, direction := range strings.Split(input, "") {
-		instructions = append(instructions, Directions[direction])
+		delta, ok := Directions[direction]
+		if !ok {
+			return nil, fmt.Errorf("invalid instruction %q, expected ^, v, < or >", direction)
+		}
+		instructions = append(instructions, delta)
 	}
-	return instructions
+	return instructions, nil
 }
 
 /* --------------------- BoxStacks Definition and Methods -------------------- */
@@ -26,3 +26,39 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// However it is instructed, the robot never walks into a wall or a box, and never loses a box.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		warehouse, err := parseWarehouse(input)
+		if err != nil {
+			return
+		}
+		wide := warehouse
+		wide.Expand()
+		boxes, wideBoxes := countBoxes(warehouse), countBoxes(wide)
+
+		for warehouse.NextInstruction() {
+		}
+		for wide.NextInstruction() {
+		}
+		assert.Equal(t, boxes, countBoxes(warehouse))
+		assert.Equal(t, wideBoxes, countBoxes(wide))
+		assert.Equal(t, FREE, warehouse.Map[warehouse.Robot.Y][warehouse.Robot.X])
+		assert.Equal(t, FREE, wide.Map[wide.Robot.Y][wide.Robot.X])
+	})
+}
+
+// Returns the number of cells of the warehouse holding a box, or half of one.
+func countBoxes(w Warehouse) int {
+	boxes := 0
+	for _, row := range w.Map {
+		for _, cell := range row {
+			if cell >= BOX {
+				boxes++
+			}
+		}
+	}
+	return boxes
+}
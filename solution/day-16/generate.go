@@ -0,0 +1,65 @@
+package day16
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/util"
+)
+
+/* -------------------------------- Generator ------------------------------- */
+
+// Generate returns a random maze of size by size tiles, with the start in the bottom left corner and
+// the end in the top right, as in the real puzzle input. The maze is carved by a randomized depth first
+// search, which guarantees a path from start to end, then one in ten of the remaining inner walls is
+// knocked down so there are loops, and more than one best path.
+func (d Puzzle) Generate(rng *rand.Rand, size int) (string, map[string]string) {
+	// Rooms sit on odd coordinates, so the maze needs an odd size to be walled in
+	size = max(size|1, 5)
+	grid := make([][]byte, size)
+	for y := range grid {
+		grid[y] = []byte(strings.Repeat("#", size))
+	}
+
+	// Carve passages between rooms, two tiles apart
+	start := util.Point{X: 1, Y: size - 2}
+	grid[start.Y][start.X] = '.'
+	stack := []util.Point{start}
+	for len(stack) > 0 {
+		room := stack[len(stack)-1]
+		unvisited := make([]util.Point, 0, 4)
+		for _, delta := range []util.Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}} {
+			next := util.Point{X: room.X + 2*delta.X, Y: room.Y + 2*delta.Y}
+			if next.X > 0 && next.X < size-1 && next.Y > 0 && next.Y < size-1 && grid[next.Y][next.X] == '#' {
+				unvisited = append(unvisited, next)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[rng.IntN(len(unvisited))]
+		grid[(room.Y+next.Y)/2][(room.X+next.X)/2] = '.'
+		grid[next.Y][next.X] = '.'
+		stack = append(stack, next)
+	}
+
+	// Knock down walls which separate two rooms
+	for y := 1; y < size-1; y++ {
+		for x := 1; x < size-1; x++ {
+			between := (x%2 == 0) != (y%2 == 0)
+			if between && grid[y][x] == '#' && rng.IntN(10) == 0 {
+				grid[y][x] = '.'
+			}
+		}
+	}
+
+	grid[start.Y][start.X] = 'S'
+	grid[1][size-2] = 'E'
+	rows := make([]string, size)
+	for y, row := range grid {
+		rows[y] = string(row)
+	}
+	return strings.Join(rows, "\n"), nil
+}
@@ -4,10 +4,13 @@
 package day16
 
 import (
+	"context"
 	"fmt"
 	"slices"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 /* ------------------------------- Main Method ------------------------------ */
@@ -15,23 +18,52 @@ type Puzzle struct{}
 
 // The Solve method is called to solve the puzzle.
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle, failing both parts if the input cannot be parsed.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
 /* -------------------------------- Solution -------------------------------- */
 
+// Visualize draws the maze with the tiles on the best paths highlighted.
+func (d Puzzle) Visualize(_ context.Context, input string) (*render.Grid, error) {
+	maze, err := NewMaze(input)
+	if err != nil {
+		return nil, err
+	}
+	tiles := make([]util.Point, 0)
+	for tile := range maze.bestPathTiles() {
+		tiles = append(tiles, tile)
+	}
+
+	return render.FromLines(util.GetLines(input), map[rune]render.Style{'#': {Color: render.Gray, Name: "wall"}}).
+		Highlight(tiles, render.Style{Char: 'O', Color: render.Green, Name: "best path"}).
+		Highlight([]util.Point{maze.Start}, render.Style{Char: 'S', Color: render.Red, Name: "start"}).
+		Highlight([]util.Point{maze.End}, render.Style{Char: 'E', Color: render.Red, Name: "end"}), nil
+}
+
 // Part 1: What is the lowest score a Reindeer could get traversing from
 //
 //	Start (S) to End (E)?
-func part1(input string) string {
-	maze := NewMaze(input)
-	return fmt.Sprintf("%d", maze.LowestScore())
+func part1(input string) answer.Answer {
+	maze, err := NewMaze(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(maze.LowestScore())
 }
 
 // Part 2: How many tiles are part of at least one of the best paths through the maze?
-func part2(input string) string {
-	maze := NewMaze(input)
-	return fmt.Sprintf("%d", maze.TilesOnBestPaths())
+func part2(input string) answer.Answer {
+	maze, err := NewMaze(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	return answer.FromInt(maze.TilesOnBestPaths())
 }
 
 /* ----------------------- Maze Definition and Methods ---------------------- */
@@ -50,6 +82,11 @@ func (m Maze) LowestScore() int {
 
 // Return the number of tiles on the map which occur in any of the "best" paths
 func (m Maze) TilesOnBestPaths() int {
+	return len(m.bestPathTiles())
+}
+
+// Return the set of tiles on the map which occur in any of the "best" paths
+func (m Maze) bestPathTiles() map[util.Point]struct{} {
 	scoreMap := m.getLowestScores()
 	queue := []util.Point{m.End}
 
@@ -65,7 +102,7 @@ func (m Maze) TilesOnBestPaths() int {
 		// We've reached the start node, we've collected all the nodes which
 		//   are part of the best paths
 		if node == m.Start {
-			return len(bestPathNodes)
+			return bestPathNodes
 		}
 
 		// Find the node which is next from our current node.
@@ -94,7 +131,7 @@ func (m Maze) TilesOnBestPaths() int {
 		}
 		queue = append(queue, subqueue...)
 	}
-	return len(bestPathNodes)
+	return bestPathNodes
 }
 
 // Return a map of vertices and the lowest score possible to reach them
@@ -168,26 +205,43 @@ var Directions = map[util.Point]int{
 }
 
 // NewMaze creates a new Maze from the input string.
-func NewMaze(input string) *Maze {
+// An error is returned unless the maze is rectangular, enclosed by walls, and has exactly one start and end.
+func NewMaze(input string) (*Maze, error) {
 	maze := &Maze{Graph: make(map[util.Point][]util.Point)}
-	grid := util.GetLines(input)
+	grid, err := util.RequireGrid(input)
+	if err != nil {
+		return nil, err
+	}
+	starts, ends := 0, 0
 
 	for y, row := range grid {
 		for x, cell := range row {
 			node := util.Point{X: x, Y: y}
+			border := x == 0 || y == 0 || x == len(row)-1 || y == len(grid)-1
+			if border && cell != '#' {
+				return nil, util.InputError{Line: y + 1, Column: x + 1, Message: "the maze must be enclosed by walls"}
+			}
 			switch cell {
 			case 'S':
 				maze.Start = node
+				starts++
 			case 'E':
 				maze.End = node
+				ends++
 			case '#':
 				continue
+			case '.':
+			default:
+				return nil, util.InputError{Line: y + 1, Column: x + 1, Message: fmt.Sprintf("unexpected %q in maze", cell)}
 			}
 			maze.Graph[node] = getEdges(node, grid)
 
 		}
 	}
-	return maze
+	if starts != 1 || ends != 1 {
+		return nil, fmt.Errorf("expected one start (S) and one end (E), found %d and %d", starts, ends)
+	}
+	return maze, nil
 }
 
 // Return a list of edges for a given node in the grid
@@ -1,6 +1,7 @@
 package day16
 
 import (
+	"math/rand/v2"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,33 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+// Every generated maze must have a path from the start to the end.
+func TestGenerate(t *testing.T) {
+	solver := Puzzle{}
+	for seed := uint64(0); seed < 10; seed++ {
+		input, _ := solver.Generate(rand.New(rand.NewPCG(seed, 0)), 21)
+		maze, err := NewMaze(input)
+		assert.NoError(t, err)
+
+		assert.Equal(t, util.Point{X: 1, Y: 19}, maze.Start)
+		assert.Equal(t, util.Point{X: 19, Y: 1}, maze.End)
+		assert.Positive(t, maze.LowestScore())
+	}
+}
+
+// Every edge of the maze leads to another tile of the maze.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		maze, err := NewMaze(input)
+		if err != nil {
+			return
+		}
+		for node, edges := range maze.Graph {
+			for _, edge := range edges {
+				assert.Contains(t, maze.Graph, edge, "edge from %v", node)
+			}
+		}
+	})
+}
@@ -4,40 +4,65 @@
 package day17
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
-	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/parse"
+	"shaneholland.dev/aoc-2024/util/trace"
 )
 
+var tracer = trace.New("day-17")
+
 /* ------------------------------- Main Method ------------------------------ */
 type Puzzle struct{}
 
 // The Solve method is called to solve the puzzle.
 func (d Puzzle) Solve(input string) (string, string) {
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle. A part fails if the input cannot be parsed, or its program
+// jumps to an instruction with the reserved combo operand.
+func (d Puzzle) SolveAnswers(_ context.Context, input string) (answer.Answer, answer.Answer) {
 	return part1(input), part2(input)
 }
 
 /* -------------------------------- Solution -------------------------------- */
 
 // Part 1: Retrieve the output after running the program on the 3-bit computer
-func part1(input string) string {
-	computer := NewComputer(input)
+func part1(input string) answer.Answer {
+	computer, err := NewComputer(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
 	for computer.RunNextInstruction() {
 		// Continue until execution halts
 	}
-	return fmt.Sprintf("%v", computer.GetOuput())
+	if computer.Err != nil {
+		return answer.FromError(computer.Err)
+	}
+	return answer.FromString(computer.GetOuput())
 }
 
 // Part 2: Determine the correct 'A' Register value which generates the Program as the output
-func part2(input string) string {
-	computer := NewComputer(input)
-	return fmt.Sprintf("%d", computer.GetSelfProducingRegister())
+func part2(input string) answer.Answer {
+	computer, err := NewComputer(input)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	register := computer.GetSelfProducingRegister()
+	if computer.Err != nil {
+		return answer.FromError(computer.Err)
+	}
+	return answer.FromInt(register)
 	
 }
 
@@ -51,8 +76,13 @@ type Computer struct {
 
 	pointer int
 	Output  []int
+	// Set if execution halted on an invalid instruction
+	Err error
 }
 
+// The opcodes of the adv, bst, out, bdv and cdv instructions, which take a combo operand
+var comboOpcodes = []int{0, 2, 5, 6, 7}
+
 // Returns the values in the output buffer as a string separated by commas
 func (c Computer) GetOuput() string {
 	output := ""
@@ -83,7 +113,9 @@ func (c *Computer) GetSelfProducingRegister() int {
 			// Continue until execution halts
 		}
 
-		if slices.Equal(c.Output, c.Program) {
+		if c.Err != nil {
+			return -1
+		} else if slices.Equal(c.Output, c.Program) {
 			return int(register)
 		} else if register >= math.Pow(8, float64(components+1)) {
 			// Failed to find the correct register value
@@ -96,37 +128,47 @@ func (c *Computer) GetSelfProducingRegister() int {
 		} else {
 			// Match found, increment the number of matches to reduce the increment factor
 			matched++
+			tracer.Info("matched", slog.Int("register", int(register)), slog.Int("digits", matched))
 		}
 	}
 }
 
-// Returns the value of a 'combo operand' for a given literal operand
-func (c Computer) GetComboOperand(operand int) int {
+// Returns the value of a 'combo operand' for a given literal operand, or an error for the reserved operand 7
+func (c Computer) GetComboOperand(operand int) (int, error) {
 	switch operand {
 	case 0, 1, 2, 3:
-		return operand
+		return operand, nil
 	case 4:
-		return c.Registers['A']
+		return c.Registers['A'], nil
 	case 5:
-		return c.Registers['B']
+		return c.Registers['B'], nil
 	case 6:
-		return c.Registers['C']
+		return c.Registers['C'], nil
 	}
-	log.Fatalf("Invalid combo operand encountered: %d", operand)
-	return -1
+	return -1, fmt.Errorf("invalid combo operand %d at instruction pointer %d", operand, c.pointer)
 }
 
 // Completes the next instruction at the instruction pointer, using the operand at the next position
 func (c *Computer) RunNextInstruction() bool {
-	if c.pointer >= len(c.Program) || c.Program[c.pointer] > 7 {
+	if c.pointer+1 >= len(c.Program) || c.Program[c.pointer] > 7 {
 		// Halt Execution
 		return false
 	}
 
 	// Literal Operand
 	literal := c.Program[c.pointer+1]
-	// Combo Operand
-	combo := c.GetComboOperand(literal)
+	// Combo Operand, which is only valid for the instructions which take one
+	combo, err := c.GetComboOperand(literal)
+	if err != nil && slices.Contains(comboOpcodes, c.Program[c.pointer]) {
+		// Halt Execution
+		c.Err = err
+		return false
+	}
+
+	if tracer.Enabled(slog.LevelDebug) {
+		tracer.Debug("instruction", slog.Int("pointer", c.pointer), slog.Int("opcode", c.Program[c.pointer]),
+			slog.Int("operand", literal), slog.Int("a", c.Registers['A']), slog.Int("b", c.Registers['B']), slog.Int("c", c.Registers['C']))
+	}
 
 	switch c.Program[c.pointer] {
 	// opcode 0 - adv
@@ -168,33 +210,77 @@ func (c *Computer) RunNextInstruction() bool {
 /* ----------------------------- Helper Methods ----------------------------- */
 
 // Generate a Computer from an input string
-func NewComputer(input string) Computer {
+// An error is returned unless the input holds registers A, B and C, followed by a program of 3 bit
+// instructions and operands, none of which use the reserved combo operand 7.
+func NewComputer(input string) (Computer, error) {
 	computer := Computer{Registers: make(map[rune]int), Program: make([]int, 0)}
 
-	for _, l := range util.GetLines(strings.Split(input, "\n\n")[0]) {
-		r, v := parseRegister(l)
+	sections := parse.Sections(input)
+	if len(sections) != 2 {
+		return Computer{}, fmt.Errorf("expected registers and a program separated by a blank line, found %d sections", len(sections))
+	}
+	for i, l := range util.GetLines(sections[0]) {
+		r, v, err := parseRegister(util.Line{Number: i + 1, Text: l})
+		if err != nil {
+			return Computer{}, err
+		}
 		computer.Registers[r] = v
 	}
-	computer.Program = parseProgram(strings.Split(input, "\n\n")[1])
-	return computer
+	for _, r := range "ABC" {
+		if _, ok := computer.Registers[r]; !ok {
+			return Computer{}, fmt.Errorf("register %c is missing", r)
+		}
+	}
+
+	program, err := parseProgram(sections[1])
+	if err != nil {
+		return Computer{}, err
+	}
+	computer.Program = program
+	return computer, nil
 }
 
-// Parse a register value from the input string
-func parseRegister(line string) (rune, int) {
-	match := regexp.MustCompile(`Register (\w): (\d+)`).FindStringSubmatch(line)
+// Parse a register value from a line of the input, which must be exactly Register A, B or C
+func parseRegister(line util.Line) (rune, int, error) {
+	key, value, ok := parse.KeyValue(line.Text)
 
-	if len(match) == 0 {
-		log.Fatalf("Unable to parse Register: %s", line)
+	if !ok || !slices.Contains([]string{"Register A", "Register B", "Register C"}, key) {
+		return 0, 0, util.InputError{Line: line.Number, Column: 1, Message: fmt.Sprintf("unable to parse register: %s", line.Text)}
+	}
+	n, err := util.ParseInt(value)
+	if err != nil {
+		return 0, 0, util.InputError{Line: line.Number, Column: 1, Message: err.Error()}
 	}
-	return []rune(match[1])[0], util.AtoI(match[2])
+	return rune(key[len(key)-1]), n, nil
 }
 
 // Retrieve the program as a list of 3 bit integers
-func parseProgram(line string) []int {
-	values := strings.Split(strings.Replace(line, "Program: ", "", 1), ",")
-	numbers := make([]int, len(values))
-	for i, v := range values {
-		numbers[i] = util.AtoI(v)
+func parseProgram(line string) ([]int, error) {
+	key, value, ok := parse.KeyValue(line)
+	if !ok || key != "Program" || value == "" {
+		return nil, fmt.Errorf("unable to parse program: %s", line)
+	}
+
+	program := make([]int, 0)
+	for _, field := range strings.Split(value, ",") {
+		n, err := util.ParseInt(field)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n > 7 {
+			return nil, fmt.Errorf("invalid program value %d, expected a 3 bit integer", n)
+		}
+		program = append(program, n)
+	}
+	if len(program)%2 != 0 {
+		return nil, fmt.Errorf("the program has an instruction without an operand")
+	}
+
+	// adv, bst, out, bdv and cdv take a combo operand, of which 7 is reserved
+	for i := 0; i < len(program); i += 2 {
+		if slices.Contains(comboOpcodes, program[i]) && program[i+1] == 7 {
+			return nil, fmt.Errorf("instruction %d uses the reserved combo operand 7", i/2+1)
+		}
 	}
-	return numbers
+	return program, nil
 }
@@ -1,6 +1,8 @@
 package day17
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +30,28 @@ func TestPart2(t *testing.T) {
 
 	assert.Equal(t, PART_2_EXPECTED, answer2)
 }
+
+func TestParseRegister(t *testing.T) {
+	_, err := NewComputer("Register A: 729\nRegister X: 0\nRegister C: 0\n\nProgram: 0,1,5,4,3,0\n")
+	assert.EqualError(t, err, "line 2, column 1: unable to parse register: Register X: 0")
+	assert.ErrorAs(t, err, &util.InputError{})
+}
+
+// Writing the parsed registers and program back out as input gives the same computer.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH_PART_1))
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH_PART_2))
+	f.Fuzz(func(t *testing.T, input string) {
+		computer, err := NewComputer(input)
+		if err != nil {
+			return
+		}
+		program := strings.ReplaceAll(strings.Trim(fmt.Sprint(computer.Program), "[]"), " ", ",")
+		registers := computer.Registers
+
+		reparsed, err := NewComputer(fmt.Sprintf("Register A: %d\nRegister B: %d\nRegister C: %d\n\nProgram: %s",
+			registers['A'], registers['B'], registers['C'], program))
+		assert.NoError(t, err)
+		assert.Equal(t, computer, reparsed)
+	})
+}
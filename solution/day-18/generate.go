@@ -0,0 +1,30 @@
+package day18
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+/* -------------------------------- Generator ------------------------------- */
+
+// Generate returns every address of a size by size memory space, except the start and the exit, in a
+// random order. Once every byte has fallen the start is walled in, so the path is always blocked
+// eventually. A fifth of the bytes have fallen in part 1.
+func (d Puzzle) Generate(rng *rand.Rand, size int) (string, map[string]string) {
+	size = max(size, 2)
+	addresses := rng.Perm(size * size)
+
+	bytes := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address == 0 || address == size*size-1 {
+			continue
+		}
+		bytes = append(bytes, fmt.Sprintf("%d,%d", address%size, address/size))
+	}
+	return strings.Join(bytes, "\n"), map[string]string{
+		"size":  strconv.Itoa(size),
+		"bytes": strconv.Itoa(len(bytes) / 5),
+	}
+}
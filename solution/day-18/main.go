@@ -4,12 +4,20 @@
 package day18
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"slices"
 	"strings"
 
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/bitset"
+	"shaneholland.dev/aoc-2024/util/dsu"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/render"
 )
 
 /* ------------------------------- Main Method ------------------------------ */
@@ -17,69 +25,111 @@ type Puzzle struct{}
 
 // The Solve method is called to solve the puzzle.
 func (d Puzzle) Solve(input string) (string, string) {
-	return part1(input), part2(input)
+	answer1, answer2 := d.SolveAnswers(context.Background(), input)
+	return answer1.String(), answer2.String()
+}
+
+// SolveAnswers solves the puzzle using the memory size and byte count from the context's parameters,
+// reporting the progress of part 2 to the context's progress.Reporter. Part 2's answer is a coordinate pair.
+func (d Puzzle) SolveAnswers(ctx context.Context, input string) (answer.Answer, answer.Answer) {
+	p := params.FromContext(ctx, d.Params())
+	return part1(input, p.Int("size"), p.Int("bytes")), part2(ctx, input, p.Int("size"))
+}
+
+// The size of the memory space, which is smaller for the example input, and the bytes fallen in part 1.
+func (d Puzzle) Params() []params.Param {
+	return []params.Param{
+		{Name: "size", Kind: params.Int, Default: "71", Usage: "The width and height of the memory space."},
+		{Name: "bytes", Kind: params.Int, Default: "1024", Usage: "The number of bytes which have fallen in part 1."},
+	}
 }
 
 /* -------------------------------- Solution -------------------------------- */
 
 // Part 1: Calculate the minimum number of steps needed to reach the exit
-func part1(input string) string {
-	memoryGrid := NewMemoryGrid(input)
-	bytes := 12 // Number of bytes to fall for test case
-	if memoryGrid.Bounds > 10 {
-		bytes = 1024 // Number of bytes to fall for puzzle input
+func part1(input string, size, bytes int) answer.Answer {
+	memoryGrid, err := NewMemoryGrid(input, size)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	if bytes > len(memoryGrid.Incoming) {
+		return answer.FromError(fmt.Errorf("only %d bytes fall in the input, but %d are needed; set the bytes parameter to the number which have fallen", len(memoryGrid.Incoming), bytes))
 	}
-
 	for i := 0; i < bytes; i++ {
 		memoryGrid.PushNextByte()
 	}
 
-	return fmt.Sprintf("%d", memoryGrid.ShortestPath())
+	return answer.FromInt(memoryGrid.ShortestPath())
 }
 
 // Part 2: Calculate coordinates of the first byte that will prevent the exit from being reachable from your starting position
-func part2(input string) string {
-	memoryGrid := NewMemoryGrid(input)
+func part2(ctx context.Context, input string, size int) answer.Answer {
+	memoryGrid, err := NewMemoryGrid(input, size)
+	if err != nil {
+		return answer.FromError(err)
+	}
+	memoryGrid.Progress = progress.FromContext(ctx)
 	// Get the first byte which blocks the path
 	position := memoryGrid.FirstBlockingByte()
+	if position == -1 {
+		return answer.FromError(errors.New("no byte blocks the exit"))
+	}
 	// Convert to X,Y coordinates
-	x := position % memoryGrid.Bounds
-	y := int(math.Floor(float64(position) / float64(memoryGrid.Bounds)))
+	return answer.FromPoint(memoryPoint(position, memoryGrid.Bounds))
+}
+
+// Visualize draws the memory space at the moment the first blocking byte falls.
+func (d Puzzle) Visualize(ctx context.Context, input string) (*render.Grid, error) {
+	memoryGrid, err := NewMemoryGrid(input, params.FromContext(ctx, d.Params()).Int("size"))
+	if err != nil {
+		return nil, err
+	}
+	incoming := slices.Clone(memoryGrid.Incoming)
+	blocking := memoryGrid.FirstBlockingByte()
+
+	corrupted := make([]util.Point, 0)
+	for _, address := range incoming[:slices.Index(incoming, blocking)+1] {
+		corrupted = append(corrupted, memoryPoint(address, memoryGrid.Bounds))
+	}
 
-	return fmt.Sprintf("%d,%d", x, y)
+	bounds := util.Point{X: memoryGrid.Bounds, Y: memoryGrid.Bounds}
+	return render.FromPoints(corrupted, bounds, render.Style{Char: '#', Color: render.Gray, Name: "corrupted"}, render.Style{Char: '.'}).
+		Highlight([]util.Point{memoryPoint(blocking, memoryGrid.Bounds)}, render.Style{Color: render.Red, Name: "blocking byte"}), nil
 }
 
 /* -------------------- MemoryGrid Definition and Methods ------------------- */
 
 // MemoryGrid which is a graph object describing vertices and edges, and a queue of incoming edges to destroy
+// If a Progress reporter is set, the bytes checked while searching for the first blocking byte are reported to it.
 type MemoryGrid struct {
 	Graph    map[int][]int
 	Bounds   int
 	Incoming []int
+	Progress *progress.Reporter
 }
 
 // Return the shortest path from (0,0) to (bounds-1, bounds-1)
 // This is a fairly simple BFS traversal
 func (mg MemoryGrid) ShortestPath() int {
 	end := (mg.Bounds * mg.Bounds) - 1
-	// seen is a map of visited and distance travelled to get there
-	seen := map[int]int{0: 0}
-	queue := []int{0}
+	// seen is the set of visited addresses, and the queue holds each address with the distance travelled to get there
+	seen := bitset.New(mg.Bounds * mg.Bounds)
+	seen.Set(0)
+	queue := [][2]int{{0, 0}}
 
 	// BFS search for end
 	for len(queue) > 0 {
-		cur := queue[0]
+		cur, d := queue[0][0], queue[0][1]
 		queue = queue[1:]
-		d := seen[cur]
 
 		if cur == end {
 			return d
 		}
 
 		for _, edge := range mg.Graph[cur] {
-			if _, ok := seen[edge]; !ok {
-				seen[edge] = d + 1
-				queue = append(queue, edge)
+			if !seen.Test(edge) {
+				seen.Set(edge)
+				queue = append(queue, [2]int{edge, d + 1})
 			}
 		}
 	}
@@ -102,28 +152,46 @@ func (mg *MemoryGrid) PushNextByte() {
 
 // Retrieve the first item in the Incoming queue which makes traversal to the end impossible
 //
-//	To solve this, I created a stack from the original Incoming queue, to process it in reverse order
-//	For each item in the stack, I rebuild the removed edges and test using ShortestPath to see if it's possible
-//	to traverse the graph.  The first item which successfully traverses, is also the first byte which makes it impossible
-//	to reach the end.
+//	To solve this, every byte is marked as fallen, and the remaining open cells are joined into connected
+//	components using a disjoint-set.  Bytes are then restored in reverse order, joining each with its open
+//	neighbors.  The first restored byte which connects the start and end components, is also the first byte
+//	which makes it impossible to reach the end.
 func (mg *MemoryGrid) FirstBlockingByte() int {
-	stack := slices.Clone(mg.Incoming)
-
-	// Push all the bytes
-	for len(mg.Incoming) > 0 {
-		mg.PushNextByte()
+	end := (mg.Bounds * mg.Bounds) - 1
+	blocked := make([]bool, mg.Bounds*mg.Bounds)
+	for _, b := range mg.Incoming {
+		blocked[b] = true
 	}
 
-	for len(stack) > 0 {
-		cur := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
+	components := dsu.New(mg.Bounds * mg.Bounds)
+	// Join an open cell to each of its open neighbors
+	join := func(node int) {
+		for _, edge := range getEdges(node%mg.Bounds, node/mg.Bounds, mg.Bounds) {
+			if !blocked[edge] {
+				components.Union(node, edge)
+			}
+		}
+	}
 
-		// Rebuild the edges
-		for _, edge := range mg.Graph[cur] {
-			mg.Graph[edge] = append(mg.Graph[edge], cur)
+	for node := range blocked {
+		if !blocked[node] {
+			join(node)
 		}
+	}
 
-		if mg.ShortestPath() != -1 {
+	// If the exit is reachable once every byte has fallen, none of them block it
+	if components.Connected(0, end) {
+		return -1
+	}
+
+	mg.Progress.Start("removing bytes", len(mg.Incoming))
+	for i := len(mg.Incoming) - 1; i >= 0; i-- {
+		cur := mg.Incoming[i]
+		blocked[cur] = false
+		join(cur)
+		mg.Progress.Add(1)
+
+		if components.Connected(0, end) {
 			return cur
 		}
 	}
@@ -132,28 +200,49 @@ func (mg *MemoryGrid) FirstBlockingByte() int {
 
 /* ----------------------------- Helper Methods ----------------------------- */
 
-func NewMemoryGrid(input string) MemoryGrid {
+// Returns a MemoryGrid of the given size, with the bytes in the input queued to fall.
+// An error is returned if a byte is not an X,Y coordinate, or falls more than once.
+func NewMemoryGrid(input string, bounds int) (MemoryGrid, error) {
 	lines := util.GetLines(input)
 	incoming := make([]int, len(lines))
-	// Determine bounds based on the data set
-	bounds := 71 // Bounds for puzzle input
-	if len(lines) < 1024 {
-		bounds = 7 // Bounds for test data
-	}
+	fallen := make(map[int]bool, len(lines))
 
 	// Parse incoming bytes
 	for i, line := range lines {
-		incoming[i] = parseMemoryAddress(line, bounds)
+		address, err := parseMemoryAddress(line, bounds)
+		if err != nil {
+			return MemoryGrid{}, util.InputError{Line: i + 1, Column: 1, Message: err.Error()}
+		}
+		if fallen[address] {
+			return MemoryGrid{}, util.InputError{Line: i + 1, Column: 1, Message: fmt.Sprintf("byte %s has already fallen", line)}
+		}
+		fallen[address] = true
+		incoming[i] = address
 	}
 
-	return MemoryGrid{Graph: generateGraph(bounds), Bounds: bounds, Incoming: incoming}
+	return MemoryGrid{Graph: generateGraph(bounds), Bounds: bounds, Incoming: incoming}, nil
 
 }
 
 // Returns the address parsed from a line of input
-func parseMemoryAddress(line string, bounds int) int {
+// Every byte must fall inside the memory space, so input of the wrong size is never silently solved.
+func parseMemoryAddress(line string, bounds int) (int, error) {
 	coords := strings.Split(line, ",")
-	return mapMemoryAddress(util.AtoI(coords[0]), util.AtoI(coords[1]), bounds)
+	if len(coords) != 2 {
+		return 0, fmt.Errorf("invalid byte %q, expected X,Y", line)
+	}
+	x, err := util.ParseInt(coords[0])
+	if err != nil {
+		return 0, err
+	}
+	y, err := util.ParseInt(coords[1])
+	if err != nil {
+		return 0, err
+	}
+	if x < 0 || y < 0 || x >= bounds || y >= bounds {
+		return 0, fmt.Errorf("byte %d,%d falls outside the %dx%d memory space. Set the size parameter to the size of the memory space", x, y, bounds, bounds)
+	}
+	return mapMemoryAddress(x, y, bounds), nil
 }
 
 // Return an integer address representing x and y coordinates on a grid
@@ -161,6 +250,11 @@ func mapMemoryAddress(x, y, bounds int) int {
 	return x + (y * bounds)
 }
 
+// Return the X and Y coordinates represented by an integer address on a grid
+func memoryPoint(address, bounds int) util.Point {
+	return util.Point{X: address % bounds, Y: int(math.Floor(float64(address) / float64(bounds)))}
+}
+
 // Generates a graph of points in a bounds x bounds grid, each connected to their neighbors to the North, South, East, and West
 //
 //	Vertices are connected bi-directionally
@@ -1,27 +1,82 @@
 package day18
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/params"
 )
 
 const PART_1_EXPECTED = "22"
 const PART_2_EXPECTED = "6,1"
 const PUZZLE_INPUT_PATH = "./test-data.txt"
 
+// The example memory space is smaller than the real one, and fewer bytes fall in part 1.
+var TEST_PARAMS = map[string]string{"size": "7", "bytes": "12"}
+
+// Returns a context carrying the parameters of the example input.
+func testContext(t *testing.T) context.Context {
+	values, err := params.New(Puzzle{}.Params(), TEST_PARAMS)
+	assert.NoError(t, err)
+	return params.WithValues(context.Background(), values)
+}
+
 func TestPart1(t *testing.T) {
 	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
 	solver := Puzzle{}
-	answer1, _ := solver.Solve(testInput)
+	answer1, _ := solver.SolveAnswers(testContext(t), testInput)
 
-	assert.Equal(t, PART_1_EXPECTED, answer1)
+	assert.Equal(t, PART_1_EXPECTED, answer1.String())
 }
 
 func TestPart2(t *testing.T) {
 	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
 	solver := Puzzle{}
-	_, answer2 := solver.Solve(testInput)
+	_, answer2 := solver.SolveAnswers(testContext(t), testInput)
+
+	assert.Equal(t, PART_2_EXPECTED, answer2.String())
+	point, ok := answer2.Point()
+	assert.True(t, ok)
+	assert.Equal(t, util.Point{X: 6, Y: 1}, point)
+}
+
+// More bytes than fall in the input fail part 1, rather than the program.
+func TestTooManyBytes(t *testing.T) {
+	testInput := util.ReadFile(PUZZLE_INPUT_PATH)
+	values, err := params.New(Puzzle{}.Params(), map[string]string{"size": "7", "bytes": "100"})
+	assert.NoError(t, err)
+	answer1, answer2 := Puzzle{}.SolveAnswers(params.WithValues(context.Background(), values), testInput)
+
+	assert.ErrorContains(t, answer1.Err(), "only 25 bytes fall in the input, but 100 are needed")
+	assert.Equal(t, PART_2_EXPECTED, answer2.String())
+}
+
+func TestNoBlockingByte(t *testing.T) {
+	values, err := params.New(Puzzle{}.Params(), map[string]string{"size": "3", "bytes": "1"})
+	assert.NoError(t, err)
+	_, answer2 := Puzzle{}.SolveAnswers(params.WithValues(context.Background(), values), "1,0\n")
+
+	assert.EqualError(t, answer2.Err(), "no byte blocks the exit")
+}
+
+// Writing the parsed bytes back out as input gives the same bytes.
+func FuzzParse(f *testing.F) {
+	f.Add(util.ReadFile(PUZZLE_INPUT_PATH))
+	f.Fuzz(func(t *testing.T, input string) {
+		memoryGrid, err := NewMemoryGrid(input, 7)
+		if err != nil {
+			return
+		}
+		lines := make([]string, len(memoryGrid.Incoming))
+		for i, address := range memoryGrid.Incoming {
+			lines[i] = fmt.Sprintf("%d,%d", address%7, address/7)
+		}
 
-	assert.Equal(t, PART_2_EXPECTED, answer2)
+		reparsed, err := NewMemoryGrid(strings.Join(lines, "\n"), 7)
+		assert.NoError(t, err)
+		assert.Equal(t, memoryGrid.Incoming, reparsed.Incoming)
+	})
 }
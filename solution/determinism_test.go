@@ -0,0 +1,38 @@
+package solution_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/determinism"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// The parameters of the days whose example input is smaller than the real one.
+var TEST_PARAMS = map[string]map[string]string{
+	"day-14": {"bounds": "11x7"},
+	"day-18": {"size": "7", "bytes": "12"},
+}
+
+// Every day must give the same answers and visualizations each time it solves its example input.
+func TestDeterminism(t *testing.T) {
+	for path, Solver := range solution.Solutions {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(path, "test-data.txt"))
+			assert.NoError(t, err)
+			ctx, err := solution.WithParams(context.Background(), Solver.Solution, TEST_PARAMS[path])
+			assert.NoError(t, err)
+
+			input := solution.NormalizeInput(Solver.Solution, string(data))
+			report := determinism.Check(ctx, Solver.Solution, input, determinism.Options{
+				Runs:      determinism.DEFAULT_RUNS,
+				VaryProcs: true,
+				MaxFrames: determinism.DEFAULT_MAX_FRAMES,
+			})
+			assert.True(t, report.Deterministic(), "%v", report.Differences)
+		})
+	}
+}
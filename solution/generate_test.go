@@ -0,0 +1,36 @@
+package solution_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// Every generator must give the same input for the same seed, and an input its day can solve.
+func TestGenerators(t *testing.T) {
+	for path, Solver := range solution.Solutions {
+		generator, ok := Solver.Solution.(solution.Generator)
+		if !ok {
+			continue
+		}
+		t.Run(path, func(t *testing.T) {
+			for _, size := range []int{1, 10, 50} {
+				input, overrides := generator.Generate(rand.New(rand.NewPCG(1, 2)), size)
+				again, _ := generator.Generate(rand.New(rand.NewPCG(1, 2)), size)
+				assert.Equal(t, input, again, "size %d", size)
+				other, _ := generator.Generate(rand.New(rand.NewPCG(3, 4)), size)
+				if size > 1 {
+					assert.NotEqual(t, input, other, "size %d", size)
+				}
+
+				ctx, err := solution.WithParams(context.Background(), Solver.Solution, overrides)
+				assert.NoError(t, err)
+				answer1, answer2 := solution.SolveAnswers(ctx, Solver.Solution, solution.NormalizeInput(Solver.Solution, input))
+				assert.True(t, answer1.Valid() && answer2.Valid(), "size %d: %v, %v", size, answer1, answer2)
+			}
+		})
+	}
+}
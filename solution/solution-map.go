@@ -4,6 +4,10 @@
 package solution
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+
 	day01 "shaneholland.dev/aoc-2024/solution/day-01"
 	day02 "shaneholland.dev/aoc-2024/solution/day-02"
 	day03 "shaneholland.dev/aoc-2024/solution/day-03"
@@ -24,30 +28,74 @@ import (
 	day18 "shaneholland.dev/aoc-2024/solution/day-18"
 )
 
-// Solver is a struct that contains the Solution and an icon for the Advent of Code problem.
+// Solver is a struct that contains the Solution, an icon and the title of the Advent of Code problem.
 type Solver struct {
 	Solution Solution
 	Icon     string
+	Title    string
 }
 
 // Solutions is a map of Solvers to the Advent of Code problems.
 var Solutions = map[string]Solver{
-	"day-01": {day01.Puzzle{}, "🕵"},
-	"day-02": {day02.Puzzle{}, "🦌"},
-	"day-03": {day03.Puzzle{}, "🧮"},
-	"day-04": {day04.Puzzle{}, "🔎"},
-	"day-05": {day05.Puzzle{}, "🖨️"},
-	"day-06": {day06.Puzzle{}, "💂"},
-	"day-07": {day07.Puzzle{}, "🌉"},
-	"day-08": {day08.Puzzle{}, "📡"},
-	"day-09": {day09.Puzzle{}, "💾"},
-	"day-10": {day10.Puzzle{}, "🥾"},
-	"day-11": {day11.Puzzle{}, "🪨"},
-	"day-12": {day12.Puzzle{}, "🪴"},
-	"day-13": {day13.Puzzle{}, "🕹️"},
-	"day-14": {day14.Puzzle{}, "🚽"},
-	"day-15": {day15.Puzzle{}, "🐠"},
-	"day-16": {day16.Puzzle{}, "🗺️"},
-	"day-17": {day17.Puzzle{}, "📺"},
-	"day-18": {day18.Puzzle{}, "🚦"},
+	"day-01": {day01.Puzzle{}, "🕵", "Historian Hysteria"},
+	"day-02": {day02.Puzzle{}, "🦌", "Red-Nosed Reports"},
+	"day-03": {day03.Puzzle{}, "🧮", "Mull It Over"},
+	"day-04": {day04.Puzzle{}, "🔎", "Ceres Search"},
+	"day-05": {day05.Puzzle{}, "🖨️", "Print Queue"},
+	"day-06": {day06.Puzzle{}, "💂", "Guard Gallivant"},
+	"day-07": {day07.Puzzle{}, "🌉", "Bridge Repair"},
+	"day-08": {day08.Puzzle{}, "📡", "Resonant Collinearity"},
+	"day-09": {day09.Puzzle{}, "💾", "Disk Fragmenter"},
+	"day-10": {day10.Puzzle{}, "🥾", "Hoof It"},
+	"day-11": {day11.Puzzle{}, "🪨", "Plutonian Pebbles"},
+	"day-12": {day12.Puzzle{}, "🪴", "Garden Groups"},
+	"day-13": {day13.Puzzle{}, "🕹️", "Claw Contraption"},
+	"day-14": {day14.Puzzle{}, "🚽", "Restroom Redoubt"},
+	"day-15": {day15.Puzzle{}, "🐠", "Warehouse Woes"},
+	"day-16": {day16.Puzzle{}, "🗺️", "Reindeer Maze"},
+	"day-17": {day17.Puzzle{}, "📺", "Chronospatial Computer"},
+	"day-18": {day18.Puzzle{}, "🚦", "RAM Run"},
+}
+
+/* -------------------------------- Variants -------------------------------- */
+
+// The name of the built-in solution to each day, which is always one of its variants.
+const BUILTIN = "builtin"
+
+// Variants are alternative solutions to the same puzzles, such as a teammate's, keyed by day (e.g. "day-17")
+// and then by the name of the variant. They are registered at runtime, from the runner's config file.
+var Variants = make(map[string]map[string]Solver)
+
+// RegisterVariant registers an alternative solution to a day under a name, replacing any variant of the
+// same name. The variant shares the icon and title of the day's built-in solution, which must exist.
+func RegisterVariant(day, name string, s Solution) error {
+	builtin, ok := Solutions[day]
+	if !ok {
+		return fmt.Errorf("no solution exists for %s", day)
+	}
+	if name == "" || name == BUILTIN {
+		return fmt.Errorf("invalid variant name %q for %s", name, day)
+	}
+	if Variants[day] == nil {
+		Variants[day] = make(map[string]Solver)
+	}
+	Variants[day][name] = Solver{s, builtin.Icon, builtin.Title}
+	return nil
+}
+
+// Variant returns the named solution to a day. The empty name is the same as BUILTIN.
+func Variant(day, name string) (Solver, bool) {
+	if name == "" || name == BUILTIN {
+		Solver, ok := Solutions[day]
+		return Solver, ok
+	}
+	Solver, ok := Variants[day][name]
+	return Solver, ok
+}
+
+// VariantNames returns the names of every solution to a day, with the built-in solution first and
+// the rest in order of name.
+func VariantNames(day string) []string {
+	names := []string{BUILTIN}
+	return append(names, slices.Sorted(maps.Keys(Variants[day]))...)
 }
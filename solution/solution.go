@@ -1,8 +1,118 @@
 // Package solution defines the logic for solving Advent of Code problems.
 package solution
 
+import (
+	"context"
+	"math/rand/v2"
+
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/answer"
+	"shaneholland.dev/aoc-2024/util/params"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
 // Solution is an interface that defines the contract for solving Advent of Code problems.
 type Solution interface {
 	// Solve returns the answers to an Advent of Code problem (part1, part2), given the puzzle input as a string.
 	Solve(string) (string, string)
 }
+
+// ContextSolver may be implemented by a Solution which can report its progress, or be cancelled.
+// The context may carry a progress.Reporter, obtained with progress.FromContext.
+type ContextSolver interface {
+	// SolveContext returns the answers to an Advent of Code problem, as Solve does.
+	SolveContext(context.Context, string) (string, string)
+}
+
+// AnswerSolver may be implemented by a Solution whose answers are more than plain text, such as
+// coordinates, or integers whose calculation may overflow. The context is passed as it is to a ContextSolver.
+type AnswerSolver interface {
+	// SolveAnswers returns the typed answers to an Advent of Code problem (part1, part2).
+	SolveAnswers(context.Context, string) (answer.Answer, answer.Answer)
+}
+
+// Parameterized may be implemented by a ContextSolver or AnswerSolver whose answers depend on parameters which are
+// not part of the input, such as the size of a grid. Solvers read them with params.FromContext.
+type Parameterized interface {
+	// Params declares the puzzle's parameters, with their values for the real puzzle input.
+	Params() []params.Param
+}
+
+// InputPolicy may be implemented by a Solution which needs whitespace within its input handled
+// differently from the default of leaving it untouched.
+type InputPolicy interface {
+	// WhitespacePolicy returns the policy applied to each line of input before it is solved.
+	WhitespacePolicy() util.WhitespacePolicy
+}
+
+// Visualizer may be implemented by a Solution which can draw a picture of its puzzle.
+// The context carries the puzzle's parameters, as it does for a ContextSolver.
+type Visualizer interface {
+	// Visualize returns a rendering of the solved puzzle, given the puzzle input as a string,
+	// or an error if the input cannot be parsed.
+	Visualize(context.Context, string) (*render.Grid, error)
+}
+
+// Animator may be implemented by a Solution whose puzzle is a step-by-step simulation.
+// The context carries the puzzle's parameters, as it does for a ContextSolver. Long simulations
+// should stop early, returning the context's error, once it is cancelled.
+type Animator interface {
+	// Animate runs the simulation for the given puzzle input, offering a frame to the Recorder after each step.
+	// An error is returned if the input cannot be parsed.
+	Animate(context.Context, string, *render.Recorder) error
+}
+
+// Generator may be implemented by a Solution which can create valid puzzle inputs of any size, so that
+// the way its running time grows with the size of its input can be measured.
+type Generator interface {
+	// Generate returns a puzzle input of the given size, which is always the same for the same random source.
+	// The meaning of size is up to each puzzle, such as the width of a grid or the number of lines.
+	// The parameters the input must be solved with are returned alongside it.
+	Generate(rng *rand.Rand, size int) (string, map[string]string)
+}
+
+// Solve returns the answers for the puzzle input as text, passing the context to the Solution if it is
+// an AnswerSolver or ContextSolver.
+func Solve(ctx context.Context, s Solution, input string) (string, string) {
+	if as, ok := s.(AnswerSolver); ok {
+		answer1, answer2 := as.SolveAnswers(ctx, input)
+		return answer1.String(), answer2.String()
+	}
+	if cs, ok := s.(ContextSolver); ok {
+		return cs.SolveContext(ctx, input)
+	}
+	return s.Solve(input)
+}
+
+// SolveAnswers returns the typed answers for the puzzle input. The text answers of a Solution which
+// is not an AnswerSolver are parsed, so that "42" is an integer and "6,1" a pair of coordinates.
+func SolveAnswers(ctx context.Context, s Solution, input string) (answer.Answer, answer.Answer) {
+	if as, ok := s.(AnswerSolver); ok {
+		return as.SolveAnswers(ctx, input)
+	}
+	answer1, answer2 := Solve(ctx, s, input)
+	return answer.Parse(answer1), answer.Parse(answer2)
+}
+
+// WithParams returns a copy of the context carrying the Solution's parameters, with the overrides applied.
+// An error is returned if an override is not a parameter of the Solution, or has an invalid value.
+func WithParams(ctx context.Context, s Solution, overrides map[string]string) (context.Context, error) {
+	var declared []params.Param
+	if p, ok := s.(Parameterized); ok {
+		declared = p.Params()
+	}
+	values, err := params.New(declared, overrides)
+	if err != nil {
+		return ctx, err
+	}
+	return params.WithValues(ctx, values), nil
+}
+
+// NormalizeInput prepares raw puzzle input for the given Solution, applying its InputPolicy if it has one.
+func NormalizeInput(s Solution, input string) string {
+	policy := util.PreserveWhitespace
+	if p, ok := s.(InputPolicy); ok {
+		policy = p.WhitespacePolicy()
+	}
+	return util.NormalizeInput(input, policy)
+}
@@ -0,0 +1,39 @@
+package solution_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// A variant which always gives the same answers.
+type fixed struct{}
+
+func (fixed) Solve(input string) (string, string) {
+	return "1", "2"
+}
+
+func TestVariants(t *testing.T) {
+	defer delete(solution.Variants, "day-05")
+	assert.Equal(t, []string{solution.BUILTIN}, solution.VariantNames("day-05"))
+
+	assert.NoError(t, solution.RegisterVariant("day-05", "zoe", fixed{}))
+	assert.NoError(t, solution.RegisterVariant("day-05", "alice", fixed{}))
+	assert.Equal(t, []string{solution.BUILTIN, "alice", "zoe"}, solution.VariantNames("day-05"))
+
+	Solver, ok := solution.Variant("day-05", "alice")
+	assert.True(t, ok)
+	assert.Equal(t, fixed{}, Solver.Solution)
+	assert.Equal(t, solution.Solutions["day-05"].Title, Solver.Title)
+
+	Solver, ok = solution.Variant("day-05", "")
+	assert.True(t, ok)
+	assert.Equal(t, solution.Solutions["day-05"], Solver)
+	_, ok = solution.Variant("day-05", "bob")
+	assert.False(t, ok)
+
+	assert.Error(t, solution.RegisterVariant("day-99", "alice", fixed{}))
+	assert.Error(t, solution.RegisterVariant("day-05", solution.BUILTIN, fixed{}))
+	assert.Error(t, solution.RegisterVariant("day-05", "", fixed{}))
+}
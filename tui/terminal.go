@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+/* ---------------------------- Terminal Control ---------------------------- */
+
+// IsTerminal returns true if the file is a terminal, rather than a pipe or regular file.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// MakeRaw puts the terminal attached to f into raw mode, so keys are read as they are pressed and
+// are not echoed. The returned function restores the terminal's previous mode.
+// The stty tool is used, so no platform specific system calls are needed.
+func MakeRaw(f *os.File) (restore func(), err error) {
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty(f, "raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() { stty(f, saved) }, nil
+}
+
+// Size returns the width and height of the terminal attached to f, or 80x24 if it cannot be found.
+func Size(f *os.File) (width, height int) {
+	size, err := stty(f, "size")
+	if err != nil {
+		return 80, 24
+	}
+	if _, err := fmt.Sscan(size, &height, &width); err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// stty runs the stty tool against the terminal attached to f, and returns its output.
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
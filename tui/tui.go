@@ -0,0 +1,437 @@
+// Package tui is a full-screen terminal dashboard for browsing and running the solutions.
+// It is drawn with ANSI escape codes only, and reads keys from any io.Reader, so it can be
+// driven by a fake terminal in tests.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"shaneholland.dev/aoc-2024/solution"
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/progress"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+// How often the screen is redrawn while a day is running.
+const REFRESH_INTERVAL = 100 * time.Millisecond
+
+// The column the detail pane starts at, on terminals wide enough to show it beside the list.
+const PANE_COLUMN = 58
+
+/* ---------------------------- Entry Definitions --------------------------- */
+
+// Status is the state of a day in the dashboard.
+type Status int
+
+const (
+	Idle Status = iota
+	Running
+	Solved
+	Failed
+)
+
+// Entry is a day shown in the dashboard, with the answers and timing of its last run.
+type Entry struct {
+	Day    int
+	Icon   string
+	Title  string
+	Status Status
+	Part1  string
+	Part2  string
+	Time   time.Duration
+	Err    error
+	// Progress of the day while it is running.
+	Progress *progress.Reporter
+}
+
+// Entries returns an entry for every registered day, in order of day.
+func Entries() []Entry {
+	entries := make([]Entry, 0, len(solution.Solutions))
+	for path, Solver := range solution.Solutions {
+		entries = append(entries, Entry{Day: util.AtoI(strings.TrimPrefix(path, "day-")), Icon: Solver.Icon, Title: Solver.Title})
+	}
+	slices.SortFunc(entries, func(a, b Entry) int { return a.Day - b.Day })
+	return entries
+}
+
+/* ---------------------- Model Definition and Methods ---------------------- */
+
+// Model is the state of the dashboard: the days, which one is selected, and what the pane shows.
+type Model struct {
+	Entries  []Entry
+	Selected int
+	Width    int
+	Height   int
+	// Lines of the selected day's visualization, shown below its answers.
+	Pane    []string
+	Started time.Time
+}
+
+// Key is a key press the dashboard responds to.
+type Key int
+
+const (
+	KeyNone Key = iota
+	KeyUp
+	KeyDown
+	KeyEnter
+	KeyQuit
+)
+
+// Move changes the selected day by delta, staying within the list.
+func (m *Model) Move(delta int) {
+	m.Selected = min(max(m.Selected+delta, 0), len(m.Entries)-1)
+}
+
+// View returns the escape codes which draw the whole screen.
+// Every piece of text is placed with an absolute cursor position, so wide characters such as
+// emoji cannot push the columns out of line.
+func (m *Model) View() string {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H\x1b[2J")
+
+	header := fmt.Sprintf(" 🎄 Advent of Code [2024] - %d days", len(m.Entries))
+	draw(&sb, 1, 1, "\x1b[1m"+truncate(header, m.Width)+"\x1b[0m")
+
+	// The list of days, scrolled so the selected day is always visible
+	rows := max(m.Height-4, 1)
+	first := max(0, m.Selected-rows+1)
+	for i := first; i < len(m.Entries) && i-first < rows; i++ {
+		entry := m.Entries[i]
+		row := 3 + i - first
+
+		marker := " "
+		if i == m.Selected {
+			marker = "\x1b[1;33m▶\x1b[0m"
+		}
+		draw(&sb, row, 1, marker)
+		draw(&sb, row, 3, fmt.Sprintf("Day %2d", entry.Day))
+		draw(&sb, row, 11, entry.Icon)
+		draw(&sb, row, 14, truncate(entry.Title, 24))
+		draw(&sb, row, 40, truncate(status(entry, m.Started), 16))
+	}
+
+	m.drawPane(&sb)
+
+	draw(&sb, m.Height, 1, truncate(" ↑/↓ select  ⏎ run  q quit", m.Width))
+	return sb.String()
+}
+
+// drawPane draws the details of the selected day to the right of the list, or below it on
+// narrow terminals.
+func (m *Model) drawPane(sb *strings.Builder) {
+	if len(m.Entries) == 0 {
+		return
+	}
+	entry := m.Entries[m.Selected]
+
+	column, row, width := PANE_COLUMN, 3, m.Width-PANE_COLUMN+1
+	if width < 20 {
+		column, row, width = 1, 4+min(len(m.Entries), max(m.Height-4, 1)), m.Width
+	}
+	bottom := m.Height - 2
+
+	lines := []string{
+		fmt.Sprintf("Day %d: %s", entry.Day, entry.Title),
+		"",
+		"Part 1: " + entry.Part1,
+		"Part 2: " + entry.Part2,
+		status(entry, m.Started),
+		"",
+	}
+	if entry.Err != nil {
+		lines[4] = "Error: " + entry.Err.Error()
+	}
+	if snapshot := entry.Progress.Snapshot(); entry.Status == Running && snapshot.Known() {
+		lines[5] = snapshot.String()
+	}
+	lines = append(lines, m.Pane...)
+
+	for i, line := range lines {
+		if row+i > bottom {
+			break
+		}
+		draw(sb, row+i, column, truncate(line, width))
+	}
+}
+
+/* ----------------------- App Definition and Methods ----------------------- */
+
+// App runs the dashboard, reading keys from In and drawing to Out.
+type App struct {
+	Out io.Writer
+	In  io.Reader
+	// Input returns the normalized puzzle input for a day.
+	Input func(day int) (string, error)
+	// Params returns the puzzle parameters to override for a day. If nil, every day uses its defaults.
+	Params func(day int) map[string]string
+
+	mu    sync.Mutex
+	model Model
+	dirty bool
+}
+
+// NewApp returns a dashboard of every registered day, for a terminal of the given size.
+func NewApp(out io.Writer, in io.Reader, width, height int, input func(day int) (string, error)) *App {
+	return &App{
+		Out:   out,
+		In:    in,
+		Input: input,
+		model: Model{Entries: Entries(), Width: width, Height: height},
+	}
+}
+
+// Run draws the dashboard and handles keys until q is pressed or the input ends.
+// The terminal is switched to its alternate screen while the dashboard runs.
+func (a *App) Run() error {
+	fmt.Fprint(a.Out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(a.Out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan Key)
+	go readKeys(a.In, keys)
+
+	ticker := time.NewTicker(REFRESH_INTERVAL)
+	defer ticker.Stop()
+
+	a.draw()
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok || key == KeyQuit {
+				return nil
+			}
+			a.handleKey(key)
+			a.draw()
+		case <-ticker.C:
+			a.mu.Lock()
+			dirty := a.dirty
+			a.mu.Unlock()
+			if dirty {
+				a.draw()
+			}
+		}
+	}
+}
+
+// Model returns a copy of the dashboard's current state.
+func (a *App) Model() Model {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	model := a.model
+	model.Entries = slices.Clone(a.model.Entries)
+	return model
+}
+
+// handleKey updates the dashboard for a key press.
+func (a *App) handleKey(key Key) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch key {
+	case KeyUp:
+		a.model.Move(-1)
+		a.model.Pane = nil
+	case KeyDown:
+		a.model.Move(1)
+		a.model.Pane = nil
+	case KeyEnter:
+		if entry := &a.model.Entries[a.model.Selected]; entry.Status != Running {
+			entry.Status = Running
+			entry.Progress = progress.New()
+			a.model.Pane = nil
+			a.model.Started = time.Now()
+			go a.solve(a.model.Selected)
+		}
+	}
+}
+
+// solve runs the day at index i of the list, showing the simulation live if it has one.
+// A day which panics fails, rather than taking down the dashboard with the terminal still in raw mode.
+func (a *App) solve(i int) {
+	defer func() {
+		if r := recover(); r != nil {
+			a.finish(i, "", "", 0, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	a.mu.Lock()
+	entry := a.model.Entries[i]
+	a.mu.Unlock()
+	Solver := solution.Solutions[fmt.Sprintf("day-%02d", entry.Day)]
+
+	input, err := a.Input(entry.Day)
+	if err != nil {
+		a.finish(i, "", "", 0, err)
+		return
+	}
+
+	var overrides map[string]string
+	if a.Params != nil {
+		overrides = a.Params(entry.Day)
+	}
+	ctx, err := solution.WithParams(context.Background(), Solver.Solution, overrides)
+	if err != nil {
+		a.finish(i, "", "", 0, err)
+		return
+	}
+
+	// Simulations are replayed into the pane while the answers are solved, and stopped once they are
+	animating, stop := context.WithCancel(ctx)
+	defer stop()
+	if animator, ok := Solver.Solution.(solution.Animator); ok {
+		go a.animate(animating, animator, input, i)
+	}
+
+	start := time.Now()
+	answer1, answer2 := solution.Solve(progress.WithReporter(ctx, entry.Progress), Solver.Solution, input)
+	elapsed := time.Since(start)
+	stop()
+	a.finish(i, answer1, answer2, elapsed, nil)
+
+	// The solved puzzle replaces any frame of its simulation left in the pane
+	if visualizer, ok := Solver.Solution.(solution.Visualizer); ok {
+		if grid, err := visualizer.Visualize(ctx, input); err == nil {
+			a.showFrame(ctx, i, grid)
+		}
+	}
+}
+
+// animate replays the day's simulation into the pane until the context is cancelled.
+// A simulation which panics or fails only loses its animation.
+func (a *App) animate(ctx context.Context, animator solution.Animator, input string, i int) {
+	defer func() { recover() }()
+	animator.Animate(ctx, input, a.watcher(ctx, i))
+}
+
+// watcher returns a Recorder which draws frames of a simulation into the pane, at most once per refresh,
+// until the context is cancelled.
+func (a *App) watcher(ctx context.Context, i int) *render.Recorder {
+	recorder := render.NewRecorder(1, 1)
+	last := time.Time{}
+	recorder.Watch = func(frame func() *render.Grid) {
+		if ctx.Err() != nil || time.Since(last) < REFRESH_INTERVAL {
+			return
+		}
+		last = time.Now()
+		a.showFrame(ctx, i, frame())
+	}
+	return recorder
+}
+
+// showFrame shows a visualization in the pane, if the day it belongs to is still selected and the
+// context has not been cancelled.
+func (a *App) showFrame(ctx context.Context, i int, grid *render.Grid) {
+	lines := strings.Split(strings.TrimRight(grid.ASCII(), "\n"), "\n")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.model.Selected == i && ctx.Err() == nil {
+		a.model.Pane = lines
+		a.dirty = true
+	}
+}
+
+// finish records the result of running the day at index i.
+func (a *App) finish(i int, answer1, answer2 string, elapsed time.Duration, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry := &a.model.Entries[i]
+	entry.Part1, entry.Part2, entry.Time, entry.Err = answer1, answer2, elapsed, err
+	entry.Status = Solved
+	if err != nil {
+		entry.Status = Failed
+	}
+	a.dirty = true
+}
+
+// draw writes the current view to the terminal.
+func (a *App) draw() {
+	a.mu.Lock()
+	view := a.model.View()
+	// Keep redrawing while a day is running, so its timer counts up
+	a.dirty = slices.ContainsFunc(a.model.Entries, func(e Entry) bool { return e.Status == Running })
+	a.mu.Unlock()
+
+	fmt.Fprint(a.Out, view)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// readKeys sends each key read from r to the channel, closing it when r ends.
+func readKeys(r io.Reader, keys chan<- Key) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		for _, key := range ParseKeys(buf[:n]) {
+			keys <- key
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// ParseKeys converts bytes read from a terminal in raw mode into key presses.
+// Arrow keys arrive as escape sequences, and j/k are accepted as alternatives to them.
+func ParseKeys(b []byte) []Key {
+	keys := make([]Key, 0)
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == 0x1b && i+2 < len(b) && b[i+1] == '[':
+			switch b[i+2] {
+			case 'A':
+				keys = append(keys, KeyUp)
+			case 'B':
+				keys = append(keys, KeyDown)
+			}
+			i += 2
+		case b[i] == 'k':
+			keys = append(keys, KeyUp)
+		case b[i] == 'j':
+			keys = append(keys, KeyDown)
+		case b[i] == '\r' || b[i] == '\n':
+			keys = append(keys, KeyEnter)
+		case b[i] == 'q' || b[i] == 0x03:
+			keys = append(keys, KeyQuit)
+		}
+	}
+	return keys
+}
+
+// status describes the result of an entry's last run.
+func status(entry Entry, started time.Time) string {
+	switch entry.Status {
+	case Running:
+		if snapshot := entry.Progress.Snapshot(); snapshot.Known() {
+			return fmt.Sprintf("⏳ %3.0f%% %v", snapshot.Fraction()*100, time.Since(started).Round(100*time.Millisecond))
+		}
+		return fmt.Sprintf("⏳ %v", time.Since(started).Round(100*time.Millisecond))
+	case Solved:
+		return fmt.Sprintf("✅ %v", entry.Time.Round(time.Microsecond))
+	case Failed:
+		return "❌ failed"
+	}
+	return ""
+}
+
+// draw writes text at a 1-based row and column.
+func draw(sb *strings.Builder, row, column int, text string) {
+	fmt.Fprintf(sb, "\x1b[%d;%dH%s", row, column, text)
+}
+
+// truncate shortens a string to at most width characters.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= max(width, 0) {
+		return s
+	}
+	return string(runes[:max(width, 0)])
+}
@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/solution"
+)
+
+// fakeTerminal is a screen buffer which understands the escape codes the dashboard draws with.
+// Cursor positioning and clearing are applied, and every other escape code is ignored.
+type fakeTerminal struct {
+	mu     sync.Mutex
+	width  int
+	screen [][]rune
+	row    int
+	column int
+}
+
+func newFakeTerminal(width, height int) *fakeTerminal {
+	t := &fakeTerminal{width: width, screen: make([][]rune, height)}
+	t.clear()
+	return t
+}
+
+func (t *fakeTerminal) clear() {
+	for y := range t.screen {
+		t.screen[y] = []rune(strings.Repeat(" ", t.width))
+	}
+}
+
+func (t *fakeTerminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	runes := []rune(string(p))
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != 0x1b {
+			if t.row < len(t.screen) && t.column < len(t.screen[t.row]) {
+				t.screen[t.row][t.column] = runes[i]
+			}
+			t.column++
+			continue
+		}
+
+		// Read the escape sequence up to its final letter
+		end := i + 2
+		for end < len(runes) && !(runes[end] >= 'A' && runes[end] <= 'Z' || runes[end] >= 'a' && runes[end] <= 'z') {
+			end++
+		}
+		params := string(runes[i+2 : end])
+		switch runes[end] {
+		case 'H':
+			position := strings.Split(params, ";")
+			if len(position) == 2 {
+				t.row, _ = strconv.Atoi(position[0])
+				t.column, _ = strconv.Atoi(position[1])
+				t.row, t.column = t.row-1, t.column-1
+			} else {
+				t.row, t.column = 0, 0
+			}
+		case 'J':
+			t.clear()
+		}
+		i = end
+	}
+	return len(p), nil
+}
+
+// Line returns the text on a 1-based row of the screen, without trailing spaces.
+func (t *fakeTerminal) Line(row int) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return strings.TrimRight(string(t.screen[row-1]), " ")
+}
+
+// Text returns the whole screen.
+func (t *fakeTerminal) Text() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := make([]string, len(t.screen))
+	for i, line := range t.screen {
+		lines[i] = strings.TrimRight(string(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reads a day's test input from its solution package.
+func testInput(day int) (string, error) {
+	data, err := os.ReadFile("../solution/day-0" + strconv.Itoa(day) + "/test-data.txt")
+	return solution.NormalizeInput(solution.Solutions["day-0"+strconv.Itoa(day)].Solution, string(data)), err
+}
+
+func TestView(t *testing.T) {
+	model := Model{
+		Entries: []Entry{
+			{Day: 1, Icon: "*", Title: "Historian Hysteria", Status: Solved, Part1: "11", Part2: "31", Time: 2 * time.Millisecond},
+			{Day: 2, Icon: "*", Title: "Red-Nosed Reports"},
+		},
+		Width:  100,
+		Height: 20,
+	}
+	terminal := newFakeTerminal(100, 20)
+	terminal.Write([]byte(model.View()))
+
+	assert.Equal(t, "▶ Day  1  *  Historian Hysteria        ✅ 2ms             Day 1: Historian Hysteria", terminal.Line(3))
+	assert.Equal(t, "  Day  2  *  Red-Nosed Reports", terminal.Line(4))
+	assert.Contains(t, terminal.Line(5), "Part 1: 11")
+	assert.Contains(t, terminal.Line(20), "q quit")
+
+	model.Move(5)
+	assert.Equal(t, 1, model.Selected)
+	model.Move(-5)
+	assert.Equal(t, 0, model.Selected)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := ParseKeys([]byte("\x1b[A\x1b[Bjk\rxq\x03"))
+	assert.Equal(t, []Key{KeyUp, KeyDown, KeyDown, KeyUp, KeyEnter, KeyQuit, KeyQuit}, keys)
+}
+
+func TestAppRun(t *testing.T) {
+	terminal := newFakeTerminal(100, 30)
+	keys, input := io.Pipe()
+	app := NewApp(terminal, keys, 100, 30, testInput)
+
+	done := make(chan error)
+	go func() { done <- app.Run() }()
+
+	// Run day 1
+	input.Write([]byte("\r"))
+	assert.Eventually(t, func() bool { return app.Model().Entries[0].Status == Solved }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "11", app.Model().Entries[0].Part1)
+	assert.Equal(t, "31", app.Model().Entries[0].Part2)
+	assert.Eventually(t, func() bool { return strings.Contains(terminal.Text(), "Part 2: 31") }, time.Second, 10*time.Millisecond)
+
+	// Move to day 6 and run it, which shows its solved map in the pane
+	input.Write([]byte("jjjjj\r"))
+	assert.Eventually(t, func() bool { return app.Model().Entries[5].Status == Solved }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 5, app.Model().Selected)
+	assert.Eventually(t, func() bool { return strings.Contains(terminal.Text(), "#") }, time.Second, 10*time.Millisecond)
+
+	input.Write([]byte("q"))
+	assert.NoError(t, <-done)
+}
+
+func TestAppInputError(t *testing.T) {
+	terminal := newFakeTerminal(100, 30)
+	keys, input := io.Pipe()
+	app := NewApp(terminal, keys, 100, 30, func(day int) (string, error) {
+		return "", os.ErrNotExist
+	})
+
+	done := make(chan error)
+	go func() { done <- app.Run() }()
+	input.Write([]byte("\r"))
+	assert.Eventually(t, func() bool { return app.Model().Entries[0].Status == Failed }, time.Second, 10*time.Millisecond)
+	input.Close()
+	assert.NoError(t, <-done)
+}
+
+// A solution which panics on any input.
+type panicSolution struct{}
+
+func (panicSolution) Solve(string) (string, string) {
+	panic("index out of range")
+}
+
+func TestAppPanic(t *testing.T) {
+	original := solution.Solutions["day-01"]
+	solution.Solutions["day-01"] = solution.Solver{Solution: panicSolution{}, Icon: original.Icon, Title: original.Title}
+	t.Cleanup(func() { solution.Solutions["day-01"] = original })
+
+	terminal := newFakeTerminal(100, 30)
+	keys, input := io.Pipe()
+	app := NewApp(terminal, keys, 100, 30, testInput)
+
+	done := make(chan error)
+	go func() { done <- app.Run() }()
+	input.Write([]byte("\r"))
+	assert.Eventually(t, func() bool { return app.Model().Entries[0].Status == Failed }, time.Second, 10*time.Millisecond)
+	assert.EqualError(t, app.Model().Entries[0].Err, "panic: index out of range")
+	input.Close()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,250 @@
+// Package answer holds the typed answer to a part of a puzzle, such as an integer, a pair of
+// coordinates or a rendered grid. Every answer has a canonical text form, which is what is
+// printed, submitted and compared, so "007", "7" and 7 are all the same answer.
+package answer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+// ErrOverflow is the error of an answer whose calculation overflowed an int64.
+var ErrOverflow = errors.New("integer overflow")
+
+/* ---------------------------- Answer Definition --------------------------- */
+
+// Kind is the type of value an answer holds.
+type Kind int
+
+const (
+	// No answer, e.g. when the puzzle timed out.
+	None Kind = iota
+	// An int64.
+	Int
+	// An integer too large for an int64.
+	Big
+	// Text, such as day 17's comma separated output.
+	String
+	// A pair of coordinates, written as "X,Y".
+	Pair
+	// A rendered grid, written as its ASCII rows.
+	Grid
+	// No answer, because the calculation failed, e.g. with ErrOverflow.
+	Error
+)
+
+// Answer is the answer to one part of a puzzle. The zero value is no answer.
+type Answer struct {
+	Kind  Kind
+	text  string
+	n     int64
+	big   *big.Int
+	point util.Point
+	err   error
+}
+
+// FromInt returns an integer answer.
+func FromInt[T ~int | ~int64](n T) Answer {
+	return Answer{Kind: Int, text: strconv.FormatInt(int64(n), 10), n: int64(n)}
+}
+
+// FromBig returns an integer answer which may be too large for an int64.
+// Integers which do fit are stored as an Int, so that equal values have the same kind.
+func FromBig(n *big.Int) Answer {
+	if n.IsInt64() {
+		return FromInt(n.Int64())
+	}
+	return Answer{Kind: Big, text: n.String(), big: new(big.Int).Set(n)}
+}
+
+// FromString returns a text answer, with surrounding whitespace removed.
+func FromString(s string) Answer {
+	return Answer{Kind: String, text: strings.TrimSpace(s)}
+}
+
+// FromPoint returns an answer which is a pair of coordinates.
+func FromPoint(p util.Point) Answer {
+	return Answer{Kind: Pair, text: fmt.Sprintf("%d,%d", p.X, p.Y), point: p}
+}
+
+// FromGrid returns an answer which is a picture, such as letters drawn by the puzzle.
+func FromGrid(g *render.Grid) Answer {
+	return Answer{Kind: Grid, text: canonicalLines(g.ASCII())}
+}
+
+// FromError returns an answer which could not be found, because of the error.
+func FromError(err error) Answer {
+	return Answer{Kind: Error, text: "error: " + err.Error(), err: err}
+}
+
+// Parse reads an answer from its text form, such as one printed by a solution or recorded by a submission.
+// Integers, and pairs of integers written as "X,Y", are parsed as such and anything else is text.
+func Parse(raw string) Answer {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return Answer{}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return FromInt(n)
+	}
+	if n, ok := new(big.Int).SetString(s, 10); ok {
+		return FromBig(n)
+	}
+	if x, y, ok := strings.Cut(s, ","); ok {
+		px, errX := strconv.Atoi(strings.TrimSpace(x))
+		py, errY := strconv.Atoi(strings.TrimSpace(y))
+		if errX == nil && errY == nil {
+			return FromPoint(util.Point{X: px, Y: py})
+		}
+	}
+	if strings.Contains(s, "\n") {
+		// The leading spaces of a grid's first row are part of the picture
+		return Answer{Kind: Grid, text: canonicalLines(strings.TrimLeft(raw, "\r\n"))}
+	}
+	return FromString(s)
+}
+
+/* ----------------------------- Answer Methods ----------------------------- */
+
+// String returns the canonical text of the answer.
+func (a Answer) String() string {
+	return a.text
+}
+
+// Equal returns true if both answers have the same canonical text.
+// Answers which are missing or failed are never equal to anything.
+func (a Answer) Equal(other Answer) bool {
+	if !a.Valid() || !other.Valid() {
+		return false
+	}
+	return a.text == other.text
+}
+
+// Valid returns true if the answer holds a value.
+func (a Answer) Valid() bool {
+	return a.Kind != None && a.Kind != Error
+}
+
+// Err returns the error of a failed answer, or nil.
+func (a Answer) Err() error {
+	return a.err
+}
+
+// Int64 returns the value of an integer answer, and false if it is not an integer which fits an int64.
+func (a Answer) Int64() (int64, bool) {
+	return a.n, a.Kind == Int
+}
+
+// BigInt returns the value of an integer answer of any size, and false if it is not an integer.
+func (a Answer) BigInt() (*big.Int, bool) {
+	switch a.Kind {
+	case Int:
+		return big.NewInt(a.n), true
+	case Big:
+		return new(big.Int).Set(a.big), true
+	}
+	return nil, false
+}
+
+// Point returns the value of a pair of coordinates, and false if the answer is not a pair.
+func (a Answer) Point() (util.Point, bool) {
+	return a.point, a.Kind == Pair
+}
+
+// MarshalJSON writes integers as JSON numbers, missing or failed answers as null, and anything else as a string.
+func (a Answer) MarshalJSON() ([]byte, error) {
+	switch a.Kind {
+	case None, Error:
+		return []byte("null"), nil
+	case Int, Big:
+		return []byte(a.text), nil
+	}
+	return json.Marshal(a.text)
+}
+
+// UnmarshalJSON reads an answer written by MarshalJSON.
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*a = Answer{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		s = n.String()
+	}
+	*a = Parse(s)
+	return nil
+}
+
+/* ---------------------------- Checked Integers ---------------------------- */
+
+// Add returns a + b, and false if the sum overflows an int64.
+func Add(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// Mul returns a * b, and false if the product overflows an int64.
+func Mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// Sum adds up integers, remembering if the total ever overflowed an int64 rather than wrapping.
+// The zero value is an empty sum.
+type Sum struct {
+	total      int64
+	overflowed bool
+}
+
+// Add adds n to the sum.
+func (s *Sum) Add(n int64) {
+	total, ok := Add(s.total, n)
+	s.total = total
+	s.overflowed = s.overflowed || !ok
+}
+
+// Overflowed returns true if the sum overflowed.
+func (s *Sum) Overflowed() bool {
+	return s.overflowed
+}
+
+// Answer returns the sum as an answer, or ErrOverflow if it overflowed.
+func (s *Sum) Answer() Answer {
+	if s.overflowed {
+		return FromError(ErrOverflow)
+	}
+	return FromInt(s.total)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// canonicalLines returns the lines of text with trailing whitespace removed from each line and the end.
+func canonicalLines(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
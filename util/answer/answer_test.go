@@ -0,0 +1,73 @@
+package answer
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util"
+	"shaneholland.dev/aoc-2024/util/render"
+)
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, FromInt(7), Parse(" 007\n"))
+	assert.Equal(t, Big, Parse("123456789012345678901234567890").Kind)
+	assert.Equal(t, FromPoint(util.Point{X: 6, Y: 1}), Parse("6, 1"))
+	assert.Equal(t, String, Parse("4,6,3,5,6,3,5,2,1,0").Kind)
+	assert.Equal(t, None, Parse("  ").Kind)
+
+	grid := Parse("\n  #\n###  \n")
+	assert.Equal(t, Grid, grid.Kind)
+	assert.Equal(t, "  #\n###", grid.String())
+}
+
+func TestEqual(t *testing.T) {
+	n, _ := new(big.Int).SetString("99999999999999999999", 10)
+	assert.True(t, FromBig(n).Equal(Parse("99999999999999999999")))
+	assert.True(t, FromBig(big.NewInt(42)).Equal(FromInt(42)))
+	assert.Equal(t, Int, FromBig(big.NewInt(42)).Kind)
+	assert.False(t, FromInt(42).Equal(FromInt(43)))
+
+	// Missing and failed answers are never equal
+	assert.False(t, Answer{}.Equal(Answer{}))
+	assert.False(t, FromError(ErrOverflow).Equal(FromError(ErrOverflow)))
+
+	grid := render.FromPoints([]util.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, util.Point{X: 2, Y: 2}, render.Style{Char: '#'}, render.Style{Char: ' '})
+	assert.Equal(t, "#\n #", FromGrid(grid).String())
+	assert.True(t, FromGrid(grid).Equal(Parse("#\n #\n")))
+}
+
+func TestJSON(t *testing.T) {
+	answers := []Answer{FromInt(22), FromPoint(util.Point{X: 6, Y: 1}), {}, FromError(ErrOverflow), Parse("123456789012345678901234567890")}
+	data, err := json.Marshal(answers)
+	assert.NoError(t, err)
+	assert.Equal(t, `[22,"6,1",null,null,123456789012345678901234567890]`, string(data))
+
+	decoded := make([]Answer, 0)
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []Answer{answers[0], answers[1], {}, {}, answers[4]}, decoded)
+}
+
+func TestChecked(t *testing.T) {
+	_, ok := Add(math.MaxInt64, 1)
+	assert.False(t, ok)
+	_, ok = Add(math.MinInt64, -1)
+	assert.False(t, ok)
+	_, ok = Mul(math.MaxInt64/2, 3)
+	assert.False(t, ok)
+	_, ok = Mul(-1, math.MinInt64)
+	assert.False(t, ok)
+	product, ok := Mul(-4, 5)
+	assert.True(t, ok)
+	assert.Equal(t, int64(-20), product)
+
+	sum := Sum{}
+	sum.Add(40)
+	sum.Add(2)
+	assert.Equal(t, FromInt(42), sum.Answer())
+	sum.Add(math.MaxInt64)
+	assert.True(t, sum.Overflowed())
+	assert.ErrorIs(t, sum.Answer().Err(), ErrOverflow)
+}
@@ -0,0 +1,161 @@
+// Package bitset provides a dense set of bits, and a 2D wrapper for tracking state on a grid.
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+
+	"shaneholland.dev/aoc-2024/util"
+)
+
+/* ---------------------- Bitset Definition and Methods --------------------- */
+
+// Bitset is a fixed size set of bits, numbered 0..Len()-1.
+type Bitset struct {
+	words []uint64
+	n     int
+}
+
+// New returns a Bitset of n bits, all clear.
+func New(n int) *Bitset {
+	return &Bitset{words: make([]uint64, (n+63)/64), n: n}
+}
+
+// Len returns the number of bits in the set.
+func (b *Bitset) Len() int {
+	return b.n
+}
+
+// Set sets bit i.
+func (b *Bitset) Set(i int) {
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+// Clear clears bit i.
+func (b *Bitset) Clear(i int) {
+	b.words[i/64] &^= 1 << (i % 64)
+}
+
+// Test returns true if bit i is set.
+func (b *Bitset) Test(i int) bool {
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Reset clears every bit.
+func (b *Bitset) Reset() {
+	clear(b.words)
+}
+
+// Count returns the number of set bits.
+func (b *Bitset) Count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// And keeps only the bits which are also set in other.
+func (b *Bitset) And(other *Bitset) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// Or sets every bit which is set in other.
+func (b *Bitset) Or(other *Bitset) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] |= other.words[i]
+	}
+}
+
+// AndNot clears every bit which is set in other.
+func (b *Bitset) AndNot(other *Bitset) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// Clone returns a copy of the Bitset.
+func (b *Bitset) Clone() *Bitset {
+	return &Bitset{words: append([]uint64(nil), b.words...), n: b.n}
+}
+
+// All returns an iterator over the set bits, in ascending order.
+func (b *Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, word := range b.words {
+			for word != 0 {
+				bit := bits.TrailingZeros64(word)
+				if !yield(i*64 + bit) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+/* ----------------------- Grid Definition and Methods ---------------------- */
+
+// Grid is a Bitset with one bit for each cell of a Width x Height grid, indexed by point.
+type Grid struct {
+	Width  int
+	Height int
+	bits   *Bitset
+}
+
+// NewGrid returns a Grid of the given size, with every cell clear.
+func NewGrid(width, height int) *Grid {
+	return &Grid{Width: width, Height: height, bits: New(width * height)}
+}
+
+// InBounds returns true if the point lies within the grid.
+func (g *Grid) InBounds(p util.Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// Set sets the cell at p.
+func (g *Grid) Set(p util.Point) {
+	g.bits.Set(p.Y*g.Width + p.X)
+}
+
+// Clear clears the cell at p.
+func (g *Grid) Clear(p util.Point) {
+	g.bits.Clear(p.Y*g.Width + p.X)
+}
+
+// Test returns true if the cell at p is set.
+func (g *Grid) Test(p util.Point) bool {
+	return g.bits.Test(p.Y*g.Width + p.X)
+}
+
+// Reset clears every cell.
+func (g *Grid) Reset() {
+	g.bits.Reset()
+}
+
+// Count returns the number of set cells.
+func (g *Grid) Count() int {
+	return g.bits.Count()
+}
+
+// Bits returns the underlying Bitset, for bulk operations between grids of the same size.
+func (g *Grid) Bits() *Bitset {
+	return g.bits
+}
+
+// Points returns an iterator over the set cells, row by row.
+func (g *Grid) Points() iter.Seq[util.Point] {
+	return func(yield func(util.Point) bool) {
+		for i := range g.bits.All() {
+			if !yield(util.Point{X: i % g.Width, Y: i / g.Width}) {
+				return
+			}
+		}
+	}
+}
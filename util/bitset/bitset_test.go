@@ -0,0 +1,59 @@
+package bitset
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util"
+)
+
+func TestBitset(t *testing.T) {
+	b := New(130)
+	b.Set(0)
+	b.Set(64)
+	b.Set(129)
+
+	assert.True(t, b.Test(64))
+	assert.False(t, b.Test(63))
+	assert.Equal(t, 3, b.Count())
+	assert.Equal(t, []int{0, 64, 129}, slices.Collect(b.All()))
+
+	b.Clear(64)
+	assert.Equal(t, []int{0, 129}, slices.Collect(b.All()))
+}
+
+func TestBulkOperations(t *testing.T) {
+	a, b := New(100), New(100)
+	for _, i := range []int{1, 2, 3, 70} {
+		a.Set(i)
+	}
+	for _, i := range []int{2, 3, 4, 99} {
+		b.Set(i)
+	}
+
+	and := a.Clone()
+	and.And(b)
+	assert.Equal(t, []int{2, 3}, slices.Collect(and.All()))
+
+	or := a.Clone()
+	or.Or(b)
+	assert.Equal(t, []int{1, 2, 3, 4, 70, 99}, slices.Collect(or.All()))
+
+	andNot := a.Clone()
+	andNot.AndNot(b)
+	assert.Equal(t, []int{1, 70}, slices.Collect(andNot.All()))
+}
+
+func TestGrid(t *testing.T) {
+	g := NewGrid(10, 5)
+	g.Set(util.Point{X: 9, Y: 0})
+	g.Set(util.Point{X: 3, Y: 4})
+
+	assert.True(t, g.Test(util.Point{X: 3, Y: 4}))
+	assert.False(t, g.InBounds(util.Point{X: 10, Y: 0}))
+	assert.Equal(t, []util.Point{{X: 9, Y: 0}, {X: 3, Y: 4}}, slices.Collect(g.Points()))
+
+	g.Reset()
+	assert.Equal(t, 0, g.Count())
+}
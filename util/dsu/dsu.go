@@ -0,0 +1,107 @@
+// Package dsu provides a disjoint-set (union-find) structure, and connected component labelling of grids built on it.
+package dsu
+
+import "shaneholland.dev/aoc-2024/util"
+
+/* ----------------------- DSU Definition and Methods ----------------------- */
+
+// DSU is a disjoint-set forest over the elements 0..n-1, using path compression and union by rank.
+type DSU struct {
+	parent []int
+	rank   []int
+	size   []int
+	sets   int
+}
+
+// New returns a DSU of n elements, each in its own set.
+func New(n int) *DSU {
+	d := &DSU{parent: make([]int, n), rank: make([]int, n), size: make([]int, n), sets: n}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+// Find returns the representative element of the set containing x.
+func (d *DSU) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Path compression: point every element on the path directly at the root
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union merges the sets containing a and b, returning false if they were already in the same set.
+func (d *DSU) Union(a, b int) bool {
+	rootA, rootB := d.Find(a), d.Find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	// Union by rank: attach the shallower tree beneath the deeper one
+	if d.rank[rootA] < d.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	d.parent[rootB] = rootA
+	d.size[rootA] += d.size[rootB]
+	if d.rank[rootA] == d.rank[rootB] {
+		d.rank[rootA]++
+	}
+	d.sets--
+	return true
+}
+
+// Connected returns true if a and b are in the same set.
+func (d *DSU) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Size returns the number of elements in the set containing x.
+func (d *DSU) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+// Sets returns the number of disjoint sets.
+func (d *DSU) Sets() int {
+	return d.sets
+}
+
+/* ------------------------ Grid Component Labelling ------------------------ */
+
+// LabelGrid labels the connected components of a width x height grid, where orthogonally adjacent cells
+// belong to the same component when connected returns true for them.
+// The labels are indexed [y][x], and numbered from 0 in the order each component is first seen when
+// scanning the grid row by row. The number of components is also returned.
+func LabelGrid(width, height int, connected func(a, b util.Point) bool) ([][]int, int) {
+	d := New(width * height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := util.Point{X: x, Y: y}
+			if x+1 < width && connected(p, util.Point{X: x + 1, Y: y}) {
+				d.Union(y*width+x, y*width+x+1)
+			}
+			if y+1 < height && connected(p, util.Point{X: x, Y: y + 1}) {
+				d.Union(y*width+x, (y+1)*width+x)
+			}
+		}
+	}
+
+	labels := make([][]int, height)
+	rootLabels := make(map[int]int)
+	for y := 0; y < height; y++ {
+		labels[y] = make([]int, width)
+		for x := 0; x < width; x++ {
+			root := d.Find(y*width + x)
+			if _, ok := rootLabels[root]; !ok {
+				rootLabels[root] = len(rootLabels)
+			}
+			labels[y][x] = rootLabels[root]
+		}
+	}
+	return labels, len(rootLabels)
+}
@@ -0,0 +1,35 @@
+package dsu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util"
+)
+
+func TestUnionFind(t *testing.T) {
+	d := New(5)
+	assert.True(t, d.Union(0, 1))
+	assert.True(t, d.Union(3, 4))
+	assert.False(t, d.Union(1, 0))
+	assert.True(t, d.Union(1, 4))
+
+	assert.True(t, d.Connected(0, 3))
+	assert.False(t, d.Connected(0, 2))
+	assert.Equal(t, 4, d.Size(3))
+	assert.Equal(t, 2, d.Sets())
+}
+
+func TestLabelGrid(t *testing.T) {
+	lines := []string{
+		"AAB",
+		"BAB",
+		"BBB",
+	}
+	labels, count := LabelGrid(3, 3, func(a, b util.Point) bool {
+		return lines[a.Y][a.X] == lines[b.Y][b.X]
+	})
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t, [][]int{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}}, labels)
+}
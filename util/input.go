@@ -0,0 +1,106 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/util/parse"
+)
+
+/* ---------------------------- Input Normalizing --------------------------- */
+
+// WhitespacePolicy controls how whitespace within the input is treated when it is normalized.
+type WhitespacePolicy int
+
+const (
+	// Leave whitespace within lines untouched.
+	PreserveWhitespace WhitespacePolicy = iota
+	// Remove trailing whitespace from every line.
+	TrimTrailingWhitespace
+	// Remove leading and trailing whitespace from every line.
+	TrimWhitespace
+)
+
+// The UTF-8 byte order mark some editors prepend to saved files.
+const byteOrderMark = "\uFEFF"
+
+// NormalizeInput prepares raw puzzle input for a solution.
+// The byte order mark is stripped, line endings are normalized by parse.Normalize, the whitespace
+// policy is applied to each line, and any trailing blank lines are removed.
+func NormalizeInput(input string, policy WhitespacePolicy) string {
+	input = strings.TrimLeft(input, byteOrderMark)
+
+	lines := strings.Split(parse.Normalize(input), "\n")
+	for i, line := range lines {
+		switch policy {
+		case TrimWhitespace:
+			line = strings.TrimSpace(line)
+		case TrimTrailingWhitespace:
+			line = strings.TrimRight(line, " \t\r")
+		}
+		lines[i] = line
+	}
+
+	// Trim trailing blank lines, including those containing only whitespace
+	for len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+/* ------------------------ Line Aware Input Helpers ------------------------ */
+
+// Line is a single line of input, along with its 1-based line number.
+type Line struct {
+	Number int
+	Text   string
+}
+
+// InputError describes a problem with the puzzle input at a given line and column.
+type InputError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error returns the error message prefixed with its position in the input.
+func (e InputError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// NonEmptyLines returns the lines of the input which are not blank, along with their line numbers.
+func NonEmptyLines(input string) []Line {
+	lines := make([]Line, 0)
+	for i, text := range GetLines(input) {
+		if strings.TrimSpace(text) != "" {
+			lines = append(lines, Line{Number: i + 1, Text: text})
+		}
+	}
+	return lines
+}
+
+// RequireNonEmptyLines returns all lines of the input, or an InputError reporting the first blank line.
+func RequireNonEmptyLines(input string) ([]Line, error) {
+	lines := make([]Line, 0)
+	for i, text := range GetLines(input) {
+		if strings.TrimSpace(text) == "" {
+			return nil, InputError{Line: i + 1, Column: 1, Message: "unexpected empty line"}
+		}
+		lines = append(lines, Line{Number: i + 1, Text: text})
+	}
+	return lines, nil
+}
+
+// RequireGrid returns the lines of a grid, or an InputError if the grid is empty or its rows differ in width.
+func RequireGrid(input string) ([]string, error) {
+	lines := GetLines(input)
+	if len(lines[0]) == 0 {
+		return nil, InputError{Line: 1, Column: 1, Message: "empty grid"}
+	}
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, InputError{Line: i + 1, Column: 1, Message: fmt.Sprintf("row is %d wide, expected %d", len(line), len(lines[0]))}
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeInput(t *testing.T) {
+	input := "\uFEFF3   4 \r\n4   3\r\n\r\n  \n"
+
+	assert.Equal(t, "3   4 \n4   3", NormalizeInput(input, PreserveWhitespace))
+	assert.Equal(t, "3   4\n4   3", NormalizeInput(input, TrimTrailingWhitespace))
+	assert.Equal(t, "125 17", NormalizeInput("  125 17  \n", TrimWhitespace))
+}
+
+func TestGetLinesTrailingNewline(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, GetLines("a\r\nb\n"))
+}
+
+func TestNonEmptyLines(t *testing.T) {
+	assert.Equal(t, []Line{{Number: 1, Text: "a"}, {Number: 3, Text: "b"}}, NonEmptyLines("a\n\nb\n"))
+
+	_, err := RequireNonEmptyLines("a\n\nb")
+	assert.EqualError(t, err, "line 2, column 1: unexpected empty line")
+}
+
+func TestRequireGrid(t *testing.T) {
+	lines, err := RequireGrid("ab\ncd\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ab", "cd"}, lines)
+
+	_, err = RequireGrid("ab\nc")
+	assert.EqualError(t, err, "line 2, column 1: row is 1 wide, expected 2")
+}
+
+/* ------------------------------ Fuzz Targets ------------------------------ */
+
+// Seeds shared by the fuzz targets of the input helpers.
+var FUZZ_SEEDS = []string{"", "\n", "3   4\r\n4   3\r\n", "\uFEFFab\ncd\n\n  \n", "MMMSXXMASM\nMSAMXMSMSA\n", "-12\n+7\n"}
+
+func FuzzNormalizeInput(f *testing.F) {
+	for _, seed := range FUZZ_SEEDS {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, policy := range []WhitespacePolicy{PreserveWhitespace, TrimTrailingWhitespace, TrimWhitespace} {
+			normalized := NormalizeInput(input, policy)
+			assert.Equal(t, normalized, NormalizeInput(normalized, policy), "normalizing must be idempotent")
+			assert.NotContains(t, normalized, "\r\n")
+		}
+	})
+}
+
+func FuzzGetLines(f *testing.F) {
+	for _, seed := range FUZZ_SEEDS {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		lines := GetLines(input)
+		assert.NotEmpty(t, lines)
+		for _, line := range lines {
+			assert.NotContains(t, line, "\n")
+		}
+		assert.Len(t, GetColumns(input), len(lines[0]))
+		if grid, err := RequireGrid(input); err == nil {
+			for _, row := range grid {
+				assert.Len(t, row, len(grid[0]))
+			}
+		}
+	})
+}
+
+func FuzzParseInt(f *testing.F) {
+	for _, seed := range []string{"0", "-12", "+7", "1x", "", "99999999999999999999"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		n, err := ParseInt(text)
+		if err == nil {
+			roundTrip, err := ParseInt(strconv.Itoa(n))
+			assert.NoError(t, err)
+			assert.Equal(t, n, roundTrip)
+		}
+	})
+}
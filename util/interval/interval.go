@@ -0,0 +1,247 @@
+// Package interval provides a sorted set of integer intervals, backed by a treap (a randomized balanced
+// binary search tree), supporting merging, removal and first-fit searches in logarithmic time.
+package interval
+
+import "iter"
+
+/* --------------------- Interval Definition and Methods -------------------- */
+
+// Interval is the half-open range of integers [Start, End).
+type Interval struct {
+	Start int
+	End   int
+}
+
+// Len returns the number of integers in the interval.
+func (i Interval) Len() int {
+	return i.End - i.Start
+}
+
+/* ----------------------- Set Definition and Methods ----------------------- */
+
+// Set is a sorted set of disjoint intervals.
+// Overlapping or adjacent intervals are merged as they are inserted.
+type Set struct {
+	root *node
+	size int
+	seed uint64
+}
+
+// A node of the treap. Nodes are ordered by the start of their interval, and heap ordered by priority.
+// maxLen is the length of the longest interval in the subtree rooted at the node.
+type node struct {
+	interval Interval
+	priority uint64
+	maxLen   int
+	left     *node
+	right    *node
+}
+
+// New returns an empty Set.
+func New() *Set {
+	return &Set{seed: 0x9e3779b97f4a7c15}
+}
+
+// Len returns the number of disjoint intervals in the set.
+func (s *Set) Len() int {
+	return s.size
+}
+
+// Insert adds the range [start, end) to the set, merging it with any overlapping or adjacent intervals.
+func (s *Set) Insert(start, end int) {
+	if start >= end {
+		return
+	}
+	merged := Interval{Start: start, End: end}
+
+	// Split into intervals starting before the range, within the range, and after it
+	before, rest := split(s.root, start)
+	within, after := split(rest, end+1)
+
+	// The last interval starting before the range may overlap or touch it
+	if last := maxNode(before); last != nil && last.interval.End >= start {
+		var removed *node
+		before, removed = split(before, last.interval.Start)
+		merged.Start = removed.interval.Start
+		merged.End = max(merged.End, removed.interval.End)
+		s.size--
+	}
+	// Every interval starting within the range is absorbed into it
+	for n := range nodes(within) {
+		merged.End = max(merged.End, n.interval.End)
+		s.size--
+	}
+
+	s.size++
+	s.root = merge(merge(before, s.newNode(merged)), after)
+}
+
+// Remove removes the range [start, end) from the set, splitting any intervals which extend beyond it.
+func (s *Set) Remove(start, end int) {
+	if start >= end {
+		return
+	}
+
+	before, rest := split(s.root, start)
+	within, after := split(rest, end)
+	remaining := make([]Interval, 0, 2)
+
+	// The last interval starting before the range may extend into it
+	if last := maxNode(before); last != nil && last.interval.End > start {
+		var removed *node
+		before, removed = split(before, last.interval.Start)
+		s.size--
+		remaining = append(remaining, Interval{Start: removed.interval.Start, End: start})
+		if removed.interval.End > end {
+			remaining = append(remaining, Interval{Start: end, End: removed.interval.End})
+		}
+	}
+	// Intervals starting within the range are removed, keeping any part which extends beyond it
+	for n := range nodes(within) {
+		s.size--
+		if n.interval.End > end {
+			remaining = append(remaining, Interval{Start: end, End: n.interval.End})
+		}
+	}
+
+	s.root = merge(before, after)
+	for _, interval := range remaining {
+		s.Insert(interval.Start, interval.End)
+	}
+}
+
+// Contains returns true if x is within an interval in the set.
+func (s *Set) Contains(x int) bool {
+	n := s.root
+	for n != nil {
+		if x < n.interval.Start {
+			n = n.left
+		} else if x >= n.interval.End {
+			n = n.right
+		} else {
+			return true
+		}
+	}
+	return false
+}
+
+// First returns the interval with the lowest start, if the set is not empty.
+func (s *Set) First() (Interval, bool) {
+	n := s.root
+	if n == nil {
+		return Interval{}, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.interval, true
+}
+
+// FirstFit returns the interval with the lowest start which is at least length long, if there is one.
+func (s *Set) FirstFit(length int) (Interval, bool) {
+	n := s.root
+	for n != nil && n.maxLen >= length {
+		if n.left != nil && n.left.maxLen >= length {
+			n = n.left
+		} else if n.interval.Len() >= length {
+			return n.interval, true
+		} else {
+			n = n.right
+		}
+	}
+	return Interval{}, false
+}
+
+// All returns an iterator over the intervals in the set, in ascending order.
+func (s *Set) All() iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		for n := range nodes(s.root) {
+			if !yield(n.interval) {
+				return
+			}
+		}
+	}
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// newNode returns a node for the interval with a pseudo-random priority.
+// The priorities are generated with xorshift, so the shape of the tree is reproducible.
+func (s *Set) newNode(interval Interval) *node {
+	s.seed ^= s.seed << 13
+	s.seed ^= s.seed >> 7
+	s.seed ^= s.seed << 17
+	return &node{interval: interval, priority: s.seed, maxLen: interval.Len()}
+}
+
+// update recalculates the maxLen of a node from its children.
+func update(n *node) *node {
+	if n != nil {
+		n.maxLen = n.interval.Len()
+		if n.left != nil {
+			n.maxLen = max(n.maxLen, n.left.maxLen)
+		}
+		if n.right != nil {
+			n.maxLen = max(n.maxLen, n.right.maxLen)
+		}
+	}
+	return n
+}
+
+// split divides a tree into the nodes starting before key, and those starting at or after key.
+func split(n *node, key int) (*node, *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.interval.Start < key {
+		left, right := split(n.right, key)
+		n.right = left
+		return update(n), right
+	}
+	left, right := split(n.left, key)
+	n.left = right
+	return left, update(n)
+}
+
+// merge joins two trees, where every node in a starts before every node in b.
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = merge(a.right, b)
+		return update(a)
+	}
+	b.left = merge(a, b.left)
+	return update(b)
+}
+
+// maxNode returns the node with the highest start in a tree.
+func maxNode(n *node) *node {
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// nodes returns an in-order iterator over a tree.
+func nodes(n *node) iter.Seq[*node] {
+	return func(yield func(*node) bool) {
+		stack := make([]*node, 0)
+		for n != nil || len(stack) > 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.left
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n) {
+				return
+			}
+			n = n.right
+		}
+	}
+}
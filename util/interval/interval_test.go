@@ -0,0 +1,93 @@
+package interval
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertMergesAdjacent(t *testing.T) {
+	set := New()
+	set.Insert(0, 2)
+	set.Insert(5, 7)
+	set.Insert(2, 4)
+
+	assert.Equal(t, []Interval{{0, 4}, {5, 7}}, slices.Collect(set.All()))
+
+	set.Insert(3, 6)
+	assert.Equal(t, []Interval{{0, 7}}, slices.Collect(set.All()))
+	assert.Equal(t, 1, set.Len())
+}
+
+func TestRemoveSplits(t *testing.T) {
+	set := New()
+	set.Insert(0, 10)
+	set.Remove(3, 5)
+
+	assert.Equal(t, []Interval{{0, 3}, {5, 10}}, slices.Collect(set.All()))
+	assert.True(t, set.Contains(2))
+	assert.False(t, set.Contains(3))
+	assert.Equal(t, 2, set.Len())
+}
+
+func TestFirstFit(t *testing.T) {
+	set := New()
+	set.Insert(0, 1)
+	set.Insert(2, 5)
+	set.Insert(8, 10)
+	set.Insert(12, 16)
+
+	fit, ok := set.FirstFit(2)
+	assert.True(t, ok)
+	assert.Equal(t, Interval{2, 5}, fit)
+
+	fit, ok = set.FirstFit(4)
+	assert.True(t, ok)
+	assert.Equal(t, Interval{12, 16}, fit)
+
+	_, ok = set.FirstFit(5)
+	assert.False(t, ok)
+}
+
+// Compare the set against a simple bitmap across a sequence of random operations.
+func TestRandomOperations(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	set := New()
+	bitmap := make([]bool, 200)
+
+	for i := 0; i < 2000; i++ {
+		start := rng.IntN(190)
+		end := start + rng.IntN(10) + 1
+		insert := rng.IntN(2) == 0
+		if insert {
+			set.Insert(start, end)
+		} else {
+			set.Remove(start, end)
+		}
+		for x := start; x < end; x++ {
+			bitmap[x] = insert
+		}
+
+		expected := make([]Interval, 0)
+		for x := 0; x < len(bitmap); x++ {
+			assert.Equal(t, bitmap[x], set.Contains(x))
+			if bitmap[x] && (x == 0 || !bitmap[x-1]) {
+				expected = append(expected, Interval{x, x + 1})
+			} else if bitmap[x] {
+				expected[len(expected)-1].End++
+			}
+		}
+		assert.Equal(t, expected, slices.Collect(set.All()))
+		assert.Equal(t, len(expected), set.Len())
+
+		length := rng.IntN(8) + 1
+		fit, ok := set.FirstFit(length)
+		index := slices.IndexFunc(expected, func(iv Interval) bool { return iv.Len() >= length })
+		assert.Equal(t, index != -1, ok)
+		if ok {
+			assert.Equal(t, expected[index], fit)
+		}
+	}
+}
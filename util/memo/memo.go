@@ -0,0 +1,257 @@
+// Package memo provides memoization and bounded caching helpers, with hit and miss statistics.
+package memo
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+/* ---------------------------- Stats Definition ---------------------------- */
+
+// Stats counts the lookups made against a cache.
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// HitRate returns the fraction of lookups which were served from the cache.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// String returns a short human readable summary of the statistics.
+func (s Stats) String() string {
+	return fmt.Sprintf("%d hits, %d misses, %d evictions (%.1f%% hit rate)", s.Hits, s.Misses, s.Evictions, s.HitRate()*100)
+}
+
+/* ---------------------- Cache Definition and Methods ---------------------- */
+
+// Cache is a least recently used cache holding at most Capacity entries.
+// A Capacity of zero means the cache is unbounded.
+// Cache is not safe for concurrent use, see SyncCache.
+type Cache[K comparable, V any] struct {
+	Capacity int
+
+	items map[K]*list.Element
+	order *list.List
+	stats Stats
+}
+
+// An entry in the cache's recency list.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewCache returns an empty Cache holding at most capacity entries, or unbounded if capacity is zero.
+func NewCache[K comparable, V any](capacity int) *Cache[K, V] {
+	return &Cache[K, V]{Capacity: capacity, items: make(map[K]*list.Element), order: list.New()}
+}
+
+// Get returns the value stored for the key, and whether it was found.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if element, ok := c.items[key]; ok {
+		c.stats.Hits++
+		c.order.MoveToFront(element)
+		return element.Value.(*entry[K, V]).value, true
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Put stores the value for the key, evicting the least recently used entry if the cache is full.
+func (c *Cache[K, V]) Put(key K, value V) {
+	if element, ok := c.items[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key, value})
+	if c.Capacity > 0 && c.order.Len() > c.Capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+		c.stats.Evictions++
+	}
+}
+
+// GetOrCompute returns the value stored for the key, computing and storing it first if it is missing.
+func (c *Cache[K, V]) GetOrCompute(key K, compute func() V) V {
+	if value, ok := c.Get(key); ok {
+		return value
+	}
+	value := compute()
+	c.Put(key, value)
+	return value
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+// Stats returns the hit and miss statistics of the cache.
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+/* -------------------- SyncCache Definition and Methods -------------------- */
+
+// SyncCache is a Cache which is safe for concurrent use.
+type SyncCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache *Cache[K, V]
+}
+
+// NewSyncCache returns an empty SyncCache holding at most capacity entries, or unbounded if capacity is zero.
+func NewSyncCache[K comparable, V any](capacity int) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: NewCache[K, V](capacity)}
+}
+
+// Get returns the value stored for the key, and whether it was found.
+func (c *SyncCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(key)
+}
+
+// Put stores the value for the key, evicting the least recently used entry if the cache is full.
+func (c *SyncCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Put(key, value)
+}
+
+// GetOrCompute returns the value stored for the key, computing and storing it first if it is missing.
+// The lock is not held while computing, so concurrent callers may compute the same value more than once.
+func (c *SyncCache[K, V]) GetOrCompute(key K, compute func() V) V {
+	if value, ok := c.Get(key); ok {
+		return value
+	}
+	value := compute()
+	c.Put(key, value)
+	return value
+}
+
+// Len returns the number of entries in the cache.
+func (c *SyncCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Len()
+}
+
+// Stats returns the hit and miss statistics of the cache.
+func (c *SyncCache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Stats()
+}
+
+/* ------------------------------- Memoization ------------------------------ */
+
+// Memoize returns a function which caches the results of the pure function f, without limit.
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	return MemoizeWith(NewCache[K, V](0), f)
+}
+
+// MemoizeWith returns a function which caches the results of the pure function f in the given cache.
+func MemoizeWith[K comparable, V any](cache *Cache[K, V], f func(K) V) func(K) V {
+	return func(key K) V {
+		return cache.GetOrCompute(key, func() V { return f(key) })
+	}
+}
+
+// MemoizeRecursive returns a memoized version of a recursive function.
+// The function is given the memoized version of itself to make its recursive calls through.
+func MemoizeRecursive[K comparable, V any](cache *Cache[K, V], f func(self func(K) V, key K) V) func(K) V {
+	var self func(K) V
+	self = MemoizeWith(cache, func(key K) V { return f(self, key) })
+	return self
+}
+
+/* ----------------------------- Tracked Caches ----------------------------- */
+
+// StatsReporter is implemented by caches which keep hit and miss statistics.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// Registry collects the caches a solution creates, so that whoever runs the solution can report their
+// statistics once it has finished. A Registry is passed to the solution in its context, and lives only as
+// long as the run it belongs to. A nil Registry ignores every cache, so solutions may track unconditionally.
+type Registry struct {
+	mu     sync.Mutex
+	caches map[string][]StatsReporter
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{caches: make(map[string][]StatsReporter)}
+}
+
+// Track registers a cache under a name. Caches tracked under the same name have their statistics combined.
+func (r *Registry) Track(name string, cache StatsReporter) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.caches[name] = append(r.caches[name], cache)
+}
+
+// Names returns the names of all tracked caches, in sorted order.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.caches))
+	for name := range r.caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Stats returns the combined statistics of all caches tracked under a name.
+func (r *Registry) Stats(name string) Stats {
+	total := Stats{}
+	if r == nil {
+		return total
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, cache := range r.caches[name] {
+		stats := cache.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Evictions += stats.Evictions
+	}
+	return total
+}
+
+// contextKey is the key a Registry is stored under in a context.
+type contextKey struct{}
+
+// WithRegistry returns a copy of the context which carries the Registry.
+func WithRegistry(ctx context.Context, r *Registry) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Registry carried by the context, or nil if it has none.
+func FromContext(ctx context.Context) *Registry {
+	r, _ := ctx.Value(contextKey{}).(*Registry)
+	return r
+}
@@ -0,0 +1,87 @@
+package memo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache[int, string](2)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)
+	cache.Put(3, "three")
+
+	_, ok := cache.Get(2)
+	assert.False(t, ok)
+	value, ok := cache.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, Stats{Hits: 2, Misses: 1, Evictions: 1}, cache.Stats())
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	assert.Equal(t, 9, square(3))
+	assert.Equal(t, 9, square(3))
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoizeRecursive(t *testing.T) {
+	cache := NewCache[int, int](0)
+	fib := MemoizeRecursive(cache, func(self func(int) int, n int) int {
+		if n < 2 {
+			return n
+		}
+		return self(n-1) + self(n-2)
+	})
+
+	assert.Equal(t, 12586269025, fib(50))
+	assert.Equal(t, 51, cache.Len())
+}
+
+func TestSyncCache(t *testing.T) {
+	cache := NewSyncCache[int, int](0)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				cache.GetOrCompute(n, func() int { return n * 2 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, cache.Len())
+	assert.Equal(t, int64(800), cache.Stats().Hits+cache.Stats().Misses)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	ctx := WithRegistry(context.Background(), registry)
+	a, b := NewCache[int, int](0), NewCache[int, int](0)
+	FromContext(ctx).Track("stones", a)
+	FromContext(ctx).Track("stones", b)
+	a.Get(1)
+	b.Put(1, 1)
+	b.Get(1)
+
+	assert.Equal(t, []string{"stones"}, registry.Names())
+	assert.Equal(t, Stats{Hits: 1, Misses: 1}, registry.Stats("stones"))
+
+	// Without a Registry, caches are not tracked anywhere
+	FromContext(context.Background()).Track("stones", a)
+	assert.Nil(t, FromContext(context.Background()).Names())
+	assert.Len(t, registry.Names(), 1)
+}
@@ -0,0 +1,104 @@
+// Package parallel runs independent pieces of work on a bounded number of goroutines.
+// Results are always returned in the order of their inputs, and reduced in that order, so a solver
+// gives the same answer however many workers it uses. The number of workers is carried by a
+// context.Context, and defaults to GOMAXPROCS.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+/* ----------------------------- Context Helpers ---------------------------- */
+
+// contextKey is the key the number of workers is stored under in a context.
+type contextKey struct{}
+
+// WithWorkers returns a copy of the context which limits work to n workers.
+// A value of zero or less leaves the default of GOMAXPROCS.
+func WithWorkers(ctx context.Context, n int) context.Context {
+	if n <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, n)
+}
+
+// Workers returns the number of workers allowed by the context, or GOMAXPROCS if it sets none.
+func Workers(ctx context.Context) int {
+	if n, ok := ctx.Value(contextKey{}).(int); ok {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+/* ------------------------------ Map and Reduce ----------------------------- */
+
+// MapState calls f for every index from 0 to n-1, on up to Workers(ctx) goroutines, and returns the
+// results in order of index. Each worker calls newState once, and passes its state to every call of
+// f it makes, so work which mutates its state (such as a copy of a grid) is never shared.
+// With a single worker, f is called in order on the calling goroutine.
+// Once the context is cancelled no more calls are started, and the remaining results are zero.
+func MapState[S, R any](ctx context.Context, n int, newState func() S, f func(S, int) R) []R {
+	results := make([]R, n)
+	workers := min(Workers(ctx), n)
+
+	if workers <= 1 {
+		state := newState()
+		for i := 0; i < n && ctx.Err() == nil; i++ {
+			results[i] = f(state, i)
+		}
+		return results
+	}
+
+	next := atomic.Int64{}
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state := newState()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				results[i] = f(state, i)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Map calls f for every item, on up to Workers(ctx) goroutines, and returns the results in order.
+func Map[T, R any](ctx context.Context, items []T, f func(T) R) []R {
+	return MapState(ctx, len(items), func() struct{} { return struct{}{} }, func(_ struct{}, i int) R {
+		return f(items[i])
+	})
+}
+
+// Reduce combines the results in order, starting from initial.
+func Reduce[R, A any](results []R, initial A, reduce func(A, R) A) A {
+	accumulator := initial
+	for _, result := range results {
+		accumulator = reduce(accumulator, result)
+	}
+	return accumulator
+}
+
+// MapReduce calls f for every item in parallel, as Map does, then combines the results in order.
+func MapReduce[T, R, A any](ctx context.Context, items []T, f func(T) R, initial A, reduce func(A, R) A) A {
+	return Reduce(Map(ctx, items, f), initial, reduce)
+}
+
+// Count returns the number of items for which f returns true, calling f in parallel as Map does.
+func Count[T any](ctx context.Context, items []T, f func(T) bool) int {
+	return MapReduce(ctx, items, f, 0, func(count int, ok bool) int {
+		if ok {
+			return count + 1
+		}
+		return count
+	})
+}
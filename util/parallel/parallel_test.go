@@ -0,0 +1,65 @@
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkers(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, runtime.GOMAXPROCS(0), Workers(ctx))
+	assert.Equal(t, 3, Workers(WithWorkers(ctx, 3)))
+	assert.Equal(t, runtime.GOMAXPROCS(0), Workers(WithWorkers(ctx, 0)))
+}
+
+func TestMapOrder(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	for _, workers := range []int{1, 4, 16} {
+		ctx := WithWorkers(context.Background(), workers)
+		squares := Map(ctx, items, func(n int) int { return n * n })
+		assert.Equal(t, 998001, squares[999])
+		assert.Equal(t, 25, squares[5])
+
+		// Reduced in order, so a non-commutative reduction gives the same result with any number of workers
+		digits := MapReduce(ctx, items[:12], func(n int) int { return n % 10 }, "", func(s string, n int) string {
+			return s + string(rune('0'+n))
+		})
+		assert.Equal(t, "012345678901", digits)
+		assert.Equal(t, 500, Count(ctx, items, func(n int) bool { return n%2 == 0 }))
+	}
+}
+
+func TestMapState(t *testing.T) {
+	created := atomic.Int64{}
+	ctx := WithWorkers(context.Background(), 4)
+	results := MapState(ctx, 100, func() *[]int {
+		created.Add(1)
+		return &[]int{}
+	}, func(seen *[]int, i int) int {
+		// Each worker's state is only ever used by that worker
+		*seen = append(*seen, i)
+		return len(*seen)
+	})
+
+	assert.Len(t, results, 100)
+	assert.LessOrEqual(t, created.Load(), int64(4))
+	assert.Empty(t, MapState(ctx, 0, func() int { return 0 }, func(int, int) int { return 1 }))
+}
+
+func TestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := atomic.Int64{}
+	Map(WithWorkers(ctx, 4), make([]int, 100), func(int) int {
+		calls.Add(1)
+		return 0
+	})
+	assert.Equal(t, int64(0), calls.Load())
+}
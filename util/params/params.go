@@ -0,0 +1,188 @@
+// Package params lets a puzzle declare the parameters its solution depends on, such as the size of
+// a grid, with defaults for the real puzzle input. Overrides are given as strings, e.g. from
+// "-param bounds=11x7", and are passed to solvers in a context.Context.
+package params
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/util"
+)
+
+/* ---------------------------- Param Definitions --------------------------- */
+
+// Kind is the type of a parameter's value.
+type Kind int
+
+const (
+	// An integer, e.g. "1024".
+	Int Kind = iota
+	// A width and height, e.g. "101x103".
+	Size
+)
+
+// Param declares a parameter of a puzzle, and its value for the real puzzle input.
+type Param struct {
+	Name    string
+	Kind    Kind
+	Default string
+	Usage   string
+}
+
+// Values are the parameters a puzzle is solved with: the declared defaults, and any overrides.
+type Values struct {
+	declared []Param
+	values   map[string]string
+}
+
+// New returns the values of the declared parameters, with the given overrides applied.
+// An error is returned if an override names a parameter which is not declared, or cannot be parsed.
+func New(declared []Param, overrides map[string]string) (*Values, error) {
+	v := &Values{declared: declared, values: make(map[string]string)}
+	for _, param := range declared {
+		v.values[param.Name] = param.Default
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		index := slices.IndexFunc(declared, func(p Param) bool { return p.Name == name })
+		if index == -1 {
+			return nil, fmt.Errorf("unknown parameter %q, expected one of: %s", name, strings.Join(Names(declared), ", "))
+		}
+		if err := validate(declared[index], overrides[name]); err != nil {
+			return nil, err
+		}
+		v.values[name] = overrides[name]
+	}
+	return v, nil
+}
+
+// Defaults returns the values of the declared parameters without any overrides.
+// It panics if a default is invalid, which is a mistake in the puzzle's declaration.
+func Defaults(declared []Param) *Values {
+	for _, param := range declared {
+		if err := validate(param, param.Default); err != nil {
+			panic(err)
+		}
+	}
+	v, _ := New(declared, nil)
+	return v
+}
+
+/* ----------------------------- Reading Values ----------------------------- */
+
+// Int returns the value of an Int parameter.
+func (v *Values) Int(name string) int {
+	n, _ := strconv.Atoi(v.get(name, Int))
+	return n
+}
+
+// Size returns the value of a Size parameter, as a point holding the width and height.
+func (v *Values) Size(name string) util.Point {
+	size, _ := parseSize(v.get(name, Size))
+	return size
+}
+
+// String lists every parameter and its value, e.g. "bounds=101x103 seconds=100".
+func (v *Values) String() string {
+	pairs := make([]string, 0, len(v.declared))
+	for _, param := range v.declared {
+		pairs = append(pairs, param.Name+"="+v.values[param.Name])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// Map returns the value of every parameter, keyed by name.
+func (v *Values) Map() map[string]string {
+	values := make(map[string]string, len(v.values))
+	for name, value := range v.values {
+		values[name] = value
+	}
+	return values
+}
+
+// get returns the value of a parameter as a string.
+// It panics if the parameter is not declared with the given kind, which is a mistake in the puzzle.
+func (v *Values) get(name string, kind Kind) string {
+	for _, param := range v.declared {
+		if param.Name == name && param.Kind == kind {
+			return v.values[name]
+		}
+	}
+	panic(fmt.Sprintf("parameter %q is not declared with this kind", name))
+}
+
+/* ----------------------------- Context Helpers ---------------------------- */
+
+// contextKey is the key Values are stored under in a context.
+type contextKey struct{}
+
+// WithValues returns a copy of the context which carries the Values.
+func WithValues(ctx context.Context, v *Values) context.Context {
+	return context.WithValue(ctx, contextKey{}, v)
+}
+
+// FromContext returns the Values carried by the context, or the defaults of the declared
+// parameters if it has none.
+func FromContext(ctx context.Context, declared []Param) *Values {
+	if v, ok := ctx.Value(contextKey{}).(*Values); ok {
+		return v
+	}
+	return Defaults(declared)
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// Names returns the names of the declared parameters.
+func Names(declared []Param) []string {
+	names := make([]string, len(declared))
+	for i, param := range declared {
+		names[i] = param.Name
+	}
+	return names
+}
+
+// validate returns an error if the value cannot be parsed as the parameter's kind.
+func validate(param Param, value string) error {
+	var err error
+	switch param.Kind {
+	case Int:
+		_, err = strconv.Atoi(value)
+	case Size:
+		_, err = parseSize(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for parameter %s: %w", value, param.Name, err)
+	}
+	return nil
+}
+
+// parseSize reads a size written as WIDTHxHEIGHT, e.g. "101x103".
+func parseSize(s string) (util.Point, error) {
+	width, height, ok := strings.Cut(strings.ToLower(s), "x")
+	if !ok {
+		return util.Point{}, fmt.Errorf("expected WIDTHxHEIGHT")
+	}
+	x, err := strconv.Atoi(width)
+	if err != nil {
+		return util.Point{}, err
+	}
+	y, err := strconv.Atoi(height)
+	if err != nil {
+		return util.Point{}, err
+	}
+	if x <= 0 || y <= 0 {
+		return util.Point{}, fmt.Errorf("width and height must be positive")
+	}
+	return util.Point{X: x, Y: y}, nil
+}
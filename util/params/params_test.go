@@ -0,0 +1,69 @@
+package params
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util"
+)
+
+var DECLARED = []Param{
+	{Name: "bounds", Kind: Size, Default: "101x103", Usage: "The size of the grid."},
+	{Name: "seconds", Kind: Int, Default: "100", Usage: "The number of seconds to simulate."},
+}
+
+func TestDefaults(t *testing.T) {
+	values := Defaults(DECLARED)
+	assert.Equal(t, util.Point{X: 101, Y: 103}, values.Size("bounds"))
+	assert.Equal(t, 100, values.Int("seconds"))
+	assert.Equal(t, "bounds=101x103 seconds=100", values.String())
+}
+
+func TestOverrides(t *testing.T) {
+	values, err := New(DECLARED, map[string]string{"bounds": "11x7"})
+	assert.NoError(t, err)
+	assert.Equal(t, util.Point{X: 11, Y: 7}, values.Size("bounds"))
+	assert.Equal(t, 100, values.Int("seconds"))
+	assert.Equal(t, map[string]string{"bounds": "11x7", "seconds": "100"}, values.Map())
+
+	_, err = New(DECLARED, map[string]string{"size": "7"})
+	assert.ErrorContains(t, err, `unknown parameter "size", expected one of: bounds, seconds`)
+
+	_, err = New(DECLARED, map[string]string{"bounds": "11"})
+	assert.ErrorContains(t, err, "invalid value")
+	_, err = New(DECLARED, map[string]string{"bounds": "0x7"})
+	assert.Error(t, err)
+	_, err = New(DECLARED, map[string]string{"seconds": "ten"})
+	assert.Error(t, err)
+}
+
+func TestWrongKind(t *testing.T) {
+	values := Defaults(DECLARED)
+	assert.Panics(t, func() { values.Int("bounds") })
+	assert.Panics(t, func() { values.Int("missing") })
+	assert.Panics(t, func() { Defaults([]Param{{Name: "n", Kind: Int, Default: "x"}}) })
+}
+
+func TestContext(t *testing.T) {
+	// Without values, the defaults are used
+	assert.Equal(t, 100, FromContext(context.Background(), DECLARED).Int("seconds"))
+
+	values, err := New(DECLARED, map[string]string{"seconds": "5"})
+	assert.NoError(t, err)
+	ctx := WithValues(context.Background(), values)
+	assert.Equal(t, 5, FromContext(ctx, DECLARED).Int("seconds"))
+}
+
+func FuzzParseSize(f *testing.F) {
+	for _, seed := range []string{"101x103", "11X7", "x", "0x5", "-1x-1", "7x"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		size, err := parseSize(s)
+		if err == nil {
+			assert.Positive(t, size.X)
+			assert.Positive(t, size.Y)
+		}
+	})
+}
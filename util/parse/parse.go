@@ -0,0 +1,174 @@
+// Package parse contains fast tokenizers for pulling integers, fields and sections out of puzzle input.
+package parse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+/* ---------------------------- String Tokenizers --------------------------- */
+
+// Ints returns every integer found in the string, in order of appearance.
+// A leading '-' or '+' is treated as the sign of the number, unless it directly follows
+// another digit (e.g. "5-3" is read as 5 and 3).
+func Ints(s string) []int {
+	ints := make([]int, 0)
+
+	for i := 0; i < len(s); i++ {
+		negative := false
+		if (s[i] == '-' || s[i] == '+') && i+1 < len(s) && isDigit(s[i+1]) && (i == 0 || !isDigit(s[i-1])) {
+			negative = s[i] == '-'
+			i++
+		}
+		if !isDigit(s[i]) {
+			continue
+		}
+
+		num := 0
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			num = num*10 + int(s[i]-'0')
+		}
+		if negative {
+			num = -num
+		}
+		ints = append(ints, num)
+
+		// Step back so the loop increment lands on the character after the number
+		i--
+	}
+	return ints
+}
+
+// Lines splits the input into lines, normalizing CRLF line endings and ignoring trailing newlines.
+func Lines(s string) []string {
+	s = Normalize(s)
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+// Sections splits the input into blocks separated by one or more blank lines.
+// Lines containing only whitespace are considered blank.
+func Sections(s string) []string {
+	sections := make([]string, 0)
+	current := make([]string, 0)
+
+	for _, line := range Lines(s) {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				sections = append(sections, strings.Join(current, "\n"))
+				current = current[:0]
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		sections = append(sections, strings.Join(current, "\n"))
+	}
+	return sections
+}
+
+// Fields splits the string on any of the given separators, dropping empty fields.
+// When no separators are given, the string is split on whitespace.
+func Fields(s string, separators ...string) []string {
+	if len(separators) == 0 {
+		return strings.Fields(s)
+	}
+
+	fields := make([]string, 0)
+	for len(s) > 0 {
+		index, width := -1, 0
+		for _, sep := range separators {
+			if sep == "" {
+				continue
+			}
+			if i := strings.Index(s, sep); i >= 0 && (index == -1 || i < index) {
+				index, width = i, len(sep)
+			}
+		}
+		if index == -1 {
+			fields = append(fields, s)
+			break
+		}
+		if index > 0 {
+			fields = append(fields, s[:index])
+		}
+		s = s[index+width:]
+	}
+	return fields
+}
+
+// KeyValue splits a line such as "Register A: 729" or "x=12" into its key and value.
+// The first ':' or '=' is used as the separator, and surrounding whitespace is trimmed.
+// ok is false if the line does not contain a separator.
+func KeyValue(line string) (key, value string, ok bool) {
+	index := strings.IndexAny(line, ":=")
+	if index == -1 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:index]), strings.TrimSpace(line[index+1:]), true
+}
+
+// Normalize converts CRLF line endings to LF and removes any trailing newlines.
+// Every carriage return ending a line is removed, so a stray one before a CRLF does not leave a CR behind.
+func Normalize(s string) string {
+	if strings.Contains(s, "\r") {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, "\r")
+		}
+		s = strings.Join(lines, "\n")
+	}
+	return strings.TrimRight(s, "\n")
+}
+
+/* --------------------- Scanner Definition and Methods --------------------- */
+
+// Scanner streams lines from a reader without loading the whole input into memory.
+// It tolerates CRLF line endings and a missing or trailing final newline.
+type Scanner struct {
+	scanner *bufio.Scanner
+	line    string
+}
+
+// NewScanner returns a Scanner reading from r.
+// Lines of up to 64MB are supported, to cope with very large single line inputs.
+func NewScanner(r io.Reader) *Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	return &Scanner{scanner: scanner}
+}
+
+// Scan advances to the next line, returning false at the end of the input or on error.
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+	s.line = strings.TrimSuffix(s.scanner.Text(), "\r")
+	return true
+}
+
+// Line returns the current line.
+func (s *Scanner) Line() string {
+	return s.line
+}
+
+// Ints returns the integers on the current line.
+func (s *Scanner) Ints() []int {
+	return Ints(s.line)
+}
+
+// Err returns the first non-EOF error encountered by the Scanner.
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// isDigit returns true if the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
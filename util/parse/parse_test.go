@@ -0,0 +1,87 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInts(t *testing.T) {
+	assert.Equal(t, []int{0, 4, 3, -3}, Ints("p=0,4 v=3,-3"))
+	assert.Equal(t, []int{94, 34}, Ints("Button A: X+94, Y+34"))
+	assert.Equal(t, []int{5, 3}, Ints("5-3"))
+	assert.Equal(t, []int{729}, Ints("Register A: 729"))
+	assert.Equal(t, []int{}, Ints("no numbers - here"))
+}
+
+func TestLines(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, Lines("a\r\nb\r\n"))
+	assert.Equal(t, []string{"a", "", "b"}, Lines("a\n\nb\n\n"))
+	assert.Equal(t, []string{}, Lines("\n"))
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "a\nb", Normalize("a\r\nb\r\n\r\n"))
+	assert.Equal(t, "a\nb", Normalize("a\r\r\nb"))
+	assert.Equal(t, "a \n\nb", Normalize("a \n\nb\n"))
+}
+
+func TestSections(t *testing.T) {
+	input := "Register A: 729\r\nRegister B: 0\r\n\r\n\r\nProgram: 0,1,5\r\n"
+	assert.Equal(t, []string{"Register A: 729\nRegister B: 0", "Program: 0,1,5"}, Sections(input))
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"3", "4"}, Fields("3   4"))
+	assert.Equal(t, []string{"190", "10", "19"}, Fields("190: 10 19", ": ", " "))
+	assert.Equal(t, []string{"47", "53"}, Fields("47|53", "|"))
+}
+
+func TestKeyValue(t *testing.T) {
+	key, value, ok := KeyValue("Register A: 729")
+	assert.True(t, ok)
+	assert.Equal(t, "Register A", key)
+	assert.Equal(t, "729", value)
+
+	_, _, ok = KeyValue("0,1,5,4,3,0")
+	assert.False(t, ok)
+}
+
+func TestScanner(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("5,4\r\n4,2\n"))
+
+	lines := [][]int{}
+	for scanner.Scan() {
+		lines = append(lines, scanner.Ints())
+	}
+
+	assert.NoError(t, scanner.Err())
+	assert.Equal(t, [][]int{{5, 4}, {4, 2}}, lines)
+}
+
+/* ------------------------------ Fuzz Targets ------------------------------ */
+
+func FuzzTokenizers(f *testing.F) {
+	for _, seed := range []string{"p=0,4 v=3,-3", "Button A: X+94, Y+34\n\nPrize: X=8400", "5-3", "Register A: 729\r\n\r\nProgram: 0,1", "47|53\n", "--5", "+"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		assert.LessOrEqual(t, len(Ints(input)), (len(input)+1)/2)
+		for _, line := range Lines(input) {
+			assert.NotContains(t, line, "\n")
+		}
+		for _, section := range Sections(input) {
+			for _, line := range strings.Split(section, "\n") {
+				assert.NotEmpty(t, strings.TrimSpace(line))
+			}
+		}
+		for _, field := range Fields(input, ": ", " ", ",") {
+			assert.NotEmpty(t, field)
+		}
+		if key, value, ok := KeyValue(input); ok {
+			assert.Equal(t, strings.TrimSpace(key), key)
+			assert.Equal(t, strings.TrimSpace(value), value)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// Package progress lets long running solvers report how far they have got, so the runner can show
+// a progress bar. A Reporter is passed to solvers in a context.Context.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/* --------------------- Reporter Definition and Methods -------------------- */
+
+// Reporter tracks the progress of a solver through named phases of work.
+// A nil Reporter ignores every update, so solvers may report unconditionally.
+type Reporter struct {
+	mu      sync.Mutex
+	phase   string
+	total   int64
+	started time.Time
+	current atomic.Int64
+}
+
+// New returns a Reporter which has not started any phase.
+func New() *Reporter {
+	return &Reporter{started: time.Now()}
+}
+
+// Start begins a new phase of work made up of total units, resetting the progress to zero.
+func (r *Reporter) Start(phase string, total int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phase, r.total, r.started = phase, int64(total), time.Now()
+	r.current.Store(0)
+}
+
+// Add records that n more units of the current phase are complete.
+// It is safe to call from several goroutines, and cheap enough to call from a hot loop.
+func (r *Reporter) Add(n int) {
+	if r == nil {
+		return
+	}
+	r.current.Add(int64(n))
+}
+
+// Set records the number of units of the current phase which are complete.
+func (r *Reporter) Set(current int) {
+	if r == nil {
+		return
+	}
+	r.current.Store(int64(current))
+}
+
+// Snapshot returns the progress made so far.
+func (r *Reporter) Snapshot() Snapshot {
+	if r == nil {
+		return Snapshot{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Snapshot{
+		Phase:   r.phase,
+		Current: int(r.current.Load()),
+		Total:   int(r.total),
+		Elapsed: time.Since(r.started),
+	}
+}
+
+/* --------------------- Snapshot Definition and Methods -------------------- */
+
+// Snapshot is the progress of a Reporter at a moment in time.
+type Snapshot struct {
+	Phase   string
+	Current int
+	Total   int
+	// Time since the phase started.
+	Elapsed time.Duration
+}
+
+// Known returns true if a phase with a known amount of work has been started.
+func (s Snapshot) Known() bool {
+	return s.Total > 0
+}
+
+// Fraction returns the fraction of the phase which is complete, between 0 and 1.
+func (s Snapshot) Fraction() float64 {
+	if s.Total <= 0 {
+		return 0
+	}
+	return min(float64(s.Current)/float64(s.Total), 1)
+}
+
+// ETA estimates the time left in the phase, assuming the rest of the work goes at the same rate.
+// Zero is returned until some progress has been made.
+func (s Snapshot) ETA() time.Duration {
+	if s.Current <= 0 || s.Total <= 0 {
+		return 0
+	}
+	remaining := max(s.Total-s.Current, 0)
+	return time.Duration(float64(s.Elapsed) * float64(remaining) / float64(s.Current))
+}
+
+// Bar returns a text progress bar of the given width, including its brackets, e.g. "[===>    ]".
+func (s Snapshot) Bar(width int) string {
+	inner := max(width-2, 1)
+	filled := int(s.Fraction() * float64(inner))
+
+	bar := strings.Repeat("=", filled)
+	if filled < inner {
+		bar += ">" + strings.Repeat(" ", inner-filled-1)
+	}
+	return "[" + bar + "]"
+}
+
+// String describes the progress, e.g. "testing obstacles [===>    ] 42% ETA 3s".
+func (s Snapshot) String() string {
+	return fmt.Sprintf("%s %s %3.0f%% ETA %v", s.Phase, s.Bar(22), s.Fraction()*100, s.ETA().Round(time.Second))
+}
+
+/* ----------------------------- Context Helpers ---------------------------- */
+
+// contextKey is the key a Reporter is stored under in a context.
+type contextKey struct{}
+
+// WithReporter returns a copy of the context which carries the Reporter.
+func WithReporter(ctx context.Context, r *Reporter) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Reporter carried by the context, or nil if it has none.
+func FromContext(ctx context.Context) *Reporter {
+	r, _ := ctx.Value(contextKey{}).(*Reporter)
+	return r
+}
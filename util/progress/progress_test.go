@@ -0,0 +1,71 @@
+package progress
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter(t *testing.T) {
+	r := New()
+	assert.False(t, r.Snapshot().Known())
+
+	r.Start("testing", 10)
+	r.Add(3)
+	r.Add(1)
+	snapshot := r.Snapshot()
+	assert.Equal(t, "testing", snapshot.Phase)
+	assert.Equal(t, 4, snapshot.Current)
+	assert.InDelta(t, 0.4, snapshot.Fraction(), 1e-9)
+
+	// Starting a new phase resets the progress
+	r.Start("checking", 5)
+	assert.Equal(t, 0, r.Snapshot().Current)
+	r.Set(5)
+	assert.Equal(t, 1.0, r.Snapshot().Fraction())
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	r := New()
+	r.Start("counting", 1000)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, r.Snapshot().Current)
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.Start("ignored", 10)
+	r.Add(1)
+	r.Set(2)
+	assert.Equal(t, Snapshot{}, r.Snapshot())
+	assert.Nil(t, FromContext(context.Background()))
+}
+
+func TestContext(t *testing.T) {
+	r := New()
+	ctx := WithReporter(context.Background(), r)
+	assert.Same(t, r, FromContext(ctx))
+}
+
+func TestSnapshotFormatting(t *testing.T) {
+	snapshot := Snapshot{Phase: "searching", Current: 25, Total: 100, Elapsed: 10 * time.Second}
+	assert.Equal(t, 30*time.Second, snapshot.ETA())
+	assert.Equal(t, "[==>     ]", Snapshot{Current: 1, Total: 4}.Bar(10))
+	assert.Equal(t, "[========]", Snapshot{Current: 4, Total: 4}.Bar(10))
+	assert.Equal(t, "searching [=====>              ]  25% ETA 30s", snapshot.String())
+	assert.Equal(t, time.Duration(0), Snapshot{Total: 100}.ETA())
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+/* --------------------- Recorder Definition and Methods -------------------- */
+
+// Recorder collects frames of a step-by-step simulation, for export as an animated GIF.
+// A nil Recorder ignores every frame, so simulations may record unconditionally.
+type Recorder struct {
+	// Keep one frame out of every Every frames recorded.
+	Every int
+	// Width and height of each grid cell, in pixels.
+	CellSize int
+	// Stop keeping frames once this many have been kept. Zero means no limit.
+	MaxFrames int
+	// Delay between frames, in hundredths of a second.
+	Delay int
+	// If set, each kept frame is passed to Watch instead of being kept as an image, so a simulation
+	// can be shown live. The frame function is only called if Watch decides to draw it.
+	Watch func(frame func() *Grid)
+
+	frames  []*image.Paletted
+	offered int
+}
+
+// The colors available to GIF frames. Cell colors are mapped to the closest of these.
+var gifPalette = color.Palette(append([]color.Color{Black, Gray}, palette...))
+
+// NewRecorder returns a Recorder which keeps one of every `every` frames, drawing each cell as a
+// square of cellSize pixels.
+func NewRecorder(every, cellSize int) *Recorder {
+	return &Recorder{Every: max(every, 1), CellSize: max(cellSize, 1), Delay: 5}
+}
+
+// Record offers a frame to the Recorder.
+// The frame function is only called when the frame is kept, so skipped frames cost nothing to render.
+func (r *Recorder) Record(frame func() *Grid) {
+	if r == nil {
+		return
+	}
+	r.offered++
+	if (r.offered-1)%max(r.Every, 1) != 0 || r.Full() {
+		return
+	}
+
+	if r.Watch != nil {
+		r.Watch(frame)
+		return
+	}
+
+	img := frame().Image(r.CellSize)
+	paletted := image.NewPaletted(img.Bounds(), gifPalette)
+	draw.Draw(paletted, img.Bounds(), img, image.Point{}, draw.Src)
+	r.frames = append(r.frames, paletted)
+}
+
+// Full returns true if no more frames will be kept, because MaxFrames have been kept, or the Recorder is nil.
+// Long simulations may stop once the Recorder is full.
+func (r *Recorder) Full() bool {
+	return r == nil || (r.MaxFrames > 0 && len(r.frames) >= r.MaxFrames)
+}
+
+// Frames returns the number of frames which have been kept.
+func (r *Recorder) Frames() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.frames)
+}
+
+// ErrNoFrames is returned when encoding a Recorder which has kept no frames.
+var ErrNoFrames = errors.New("no frames recorded")
+
+// EncodeGIF writes the kept frames to w as an animated GIF which loops forever.
+func (r *Recorder) EncodeGIF(w io.Writer) error {
+	if r.Frames() == 0 {
+		return ErrNoFrames
+	}
+	animation := &gif.GIF{}
+	for _, frame := range r.frames {
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, r.Delay)
+	}
+	return gif.EncodeAll(w, animation)
+}
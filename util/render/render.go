@@ -0,0 +1,230 @@
+// Package render draws grids and point sets as plain ASCII, ANSI colored terminal output, or PNG images.
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"shaneholland.dev/aoc-2024/util"
+)
+
+/* --------------------------------- Colors --------------------------------- */
+
+// Named colors used by the puzzles when styling cells.
+var (
+	Black   = color.RGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff}
+	Gray    = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	White   = color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff}
+	Red     = color.RGBA{R: 0xe0, G: 0x3c, B: 0x31, A: 0xff}
+	Green   = color.RGBA{R: 0x3c, G: 0xb3, B: 0x71, A: 0xff}
+	Blue    = color.RGBA{R: 0x41, G: 0x69, B: 0xe1, A: 0xff}
+	Yellow  = color.RGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff}
+	Cyan    = color.RGBA{R: 0x00, G: 0xce, B: 0xd1, A: 0xff}
+	Magenta = color.RGBA{R: 0xc7, G: 0x15, B: 0x85, A: 0xff}
+	Orange  = color.RGBA{R: 0xff, G: 0x8c, B: 0x00, A: 0xff}
+)
+
+// A set of easily distinguished colors, used when an arbitrary number of things need their own color.
+var palette = []color.Color{Red, Green, Blue, Yellow, Cyan, Magenta, Orange, White}
+
+// Palette returns a color for the i-th item in a set, cycling through a fixed palette.
+func Palette(i int) color.Color {
+	return palette[util.AbsInt(i)%len(palette)]
+}
+
+/* ---------------------------- Style Definition ---------------------------- */
+
+// Style describes how a single cell is drawn.
+// Char is the character used for text output, and Color is used for ANSI and PNG output.
+// A nil Color is drawn in the terminal's default color.
+// Name, if set, is shown in the legend of ANSI output.
+type Style struct {
+	Char  rune
+	Color color.Color
+	Name  string
+}
+
+// StyleFunc returns the Style of the cell at a given point.
+type StyleFunc func(p util.Point) Style
+
+// Overlay highlights a set of points on top of a grid, such as a path or a visited set.
+// If the Style has no Char, the underlying cell's character is kept and only recolored.
+type Overlay struct {
+	Points map[util.Point]struct{}
+	Style  Style
+}
+
+/* ----------------------- Grid Definition and Methods ---------------------- */
+
+// Grid is a rectangular area of cells which can be rendered in several formats.
+type Grid struct {
+	Width    int
+	Height   int
+	Style    StyleFunc
+	Overlays []Overlay
+}
+
+// NewGrid returns a Grid of the given size, whose cells are styled by the StyleFunc.
+func NewGrid(width, height int, style StyleFunc) *Grid {
+	return &Grid{Width: width, Height: height, Style: style}
+}
+
+// FromLines returns a Grid of the characters in lines, styling each character using the styles map.
+// Characters without an entry in the map are drawn as themselves with no color.
+func FromLines(lines []string, styles map[rune]Style) *Grid {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+
+	return NewGrid(width, len(lines), func(p util.Point) Style {
+		if p.X >= len(lines[p.Y]) {
+			return Style{Char: ' '}
+		}
+		char := rune(lines[p.Y][p.X])
+		if style, ok := styles[char]; ok {
+			if style.Char == 0 {
+				style.Char = char
+			}
+			return style
+		}
+		return Style{Char: char}
+	})
+}
+
+// FromPoints returns a Grid of the given bounds, where the points are drawn with the on Style
+// and every other cell is drawn with the off Style.
+func FromPoints(points []util.Point, bounds util.Point, on, off Style) *Grid {
+	set := toSet(points)
+	return NewGrid(bounds.X, bounds.Y, func(p util.Point) Style {
+		if _, ok := set[p]; ok {
+			return on
+		}
+		return off
+	})
+}
+
+// Highlight adds an overlay of points drawn with the given Style, and returns the Grid for chaining.
+// Overlays are drawn in the order they are added, so later overlays take precedence.
+func (g *Grid) Highlight(points []util.Point, style Style) *Grid {
+	g.Overlays = append(g.Overlays, Overlay{Points: toSet(points), Style: style})
+	return g
+}
+
+// At returns the Style of the cell at a given point, after applying any overlays.
+func (g *Grid) At(p util.Point) Style {
+	style := g.Style(p)
+	for _, overlay := range g.Overlays {
+		if _, ok := overlay.Points[p]; ok {
+			char := style.Char
+			style = overlay.Style
+			if style.Char == 0 {
+				style.Char = char
+			}
+		}
+	}
+	return style
+}
+
+// ASCII returns the grid as plain text, with each row terminated by a newline.
+func (g *Grid) ASCII() string {
+	var sb strings.Builder
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			sb.WriteRune(g.At(util.Point{X: x, Y: y}).Char)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ANSI returns the grid as text colored with ANSI escape codes, followed by a legend of named styles.
+func (g *Grid) ANSI() string {
+	var sb strings.Builder
+	legend := make([]Style, 0)
+	named := make(map[string]struct{})
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			style := g.At(util.Point{X: x, Y: y})
+			sb.WriteString(colorize(style.Char, style.Color))
+
+			if _, ok := named[style.Name]; style.Name != "" && !ok {
+				named[style.Name] = struct{}{}
+				legend = append(legend, style)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(legend) > 0 {
+		sb.WriteString("\n")
+		for i, style := range legend {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(fmt.Sprintf("%s %s", colorize(style.Char, style.Color), style.Name))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Image returns the grid as an image, with each cell drawn as a square of cellSize pixels.
+func (g *Grid) Image(cellSize int) *image.RGBA {
+	cellSize = max(cellSize, 1)
+	img := image.NewRGBA(image.Rect(0, 0, g.Width*cellSize, g.Height*cellSize))
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			fill := cellColor(g.At(util.Point{X: x, Y: y}))
+			for py := y * cellSize; py < (y+1)*cellSize; py++ {
+				for px := x * cellSize; px < (x+1)*cellSize; px++ {
+					img.Set(px, py, fill)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// PNG encodes the grid as a PNG image, with each cell drawn as a square of cellSize pixels.
+func (g *Grid) PNG(w io.Writer, cellSize int) error {
+	return png.Encode(w, g.Image(cellSize))
+}
+
+/* ----------------------------- Helper Methods ----------------------------- */
+
+// colorize wraps the character in a 24-bit ANSI color escape code, if it has a color.
+func colorize(char rune, c color.Color) string {
+	if c == nil {
+		return string(char)
+	}
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%c\x1b[0m", rgba.R, rgba.G, rgba.B, char)
+}
+
+// cellColor returns the color used to fill a cell in an image.
+// Uncolored cells are drawn dark when empty, and light otherwise.
+func cellColor(style Style) color.Color {
+	if style.Color != nil {
+		return style.Color
+	}
+	if style.Char == ' ' || style.Char == '.' || style.Char == 0 {
+		return Black
+	}
+	return Gray
+}
+
+// toSet converts a list of points into a set.
+func toSet(points []util.Point) map[util.Point]struct{} {
+	set := make(map[util.Point]struct{}, len(points))
+	for _, p := range points {
+		set[p] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"shaneholland.dev/aoc-2024/util"
+)
+
+func TestASCII(t *testing.T) {
+	grid := FromLines([]string{"#.#", "..."}, nil)
+	grid.Highlight([]util.Point{{X: 1, Y: 1}}, Style{Char: 'X'})
+
+	assert.Equal(t, "#.#\n.X.\n", grid.ASCII())
+}
+
+func TestFromPoints(t *testing.T) {
+	grid := FromPoints([]util.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, util.Point{X: 2, Y: 2}, Style{Char: '#'}, Style{Char: '.'})
+
+	assert.Equal(t, "#.\n.#\n", grid.ASCII())
+}
+
+func TestANSILegend(t *testing.T) {
+	grid := FromLines([]string{"#."}, map[rune]Style{'#': {Color: Red, Name: "wall"}})
+
+	assert.Equal(t, "\x1b[38;2;224;60;49m#\x1b[0m.\n\n\x1b[38;2;224;60;49m#\x1b[0m wall\n", grid.ANSI())
+}
+
+func TestPNG(t *testing.T) {
+	grid := FromLines([]string{"#.", ".#"}, nil)
+	buf := bytes.Buffer{}
+
+	assert.NoError(t, grid.PNG(&buf, 4))
+
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, img.Bounds().Dx())
+	assert.Equal(t, 8, img.Bounds().Dy())
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder(2, 1)
+	rendered := 0
+	for i := 0; i < 5; i++ {
+		recorder.Record(func() *Grid {
+			rendered++
+			return FromLines([]string{"#."}, nil)
+		})
+	}
+
+	assert.Equal(t, 3, recorder.Frames())
+	assert.Equal(t, 3, rendered)
+	assert.False(t, recorder.Full())
+	recorder.MaxFrames = 3
+	assert.True(t, recorder.Full())
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, recorder.EncodeGIF(&buf))
+
+	animation, err := gif.DecodeAll(&buf)
+	assert.NoError(t, err)
+	assert.Len(t, animation.Image, 3)
+}
+
+func TestNilRecorder(t *testing.T) {
+	var recorder *Recorder
+	recorder.Record(func() *Grid {
+		t.Fatal("nil recorder should not render frames")
+		return nil
+	})
+	assert.Equal(t, 0, recorder.Frames())
+	assert.True(t, recorder.Full())
+	assert.ErrorIs(t, recorder.EncodeGIF(&bytes.Buffer{}), ErrNoFrames)
+}
+
+func TestRecorderWatch(t *testing.T) {
+	recorder := NewRecorder(1, 1)
+	watched := make([]string, 0)
+	recorder.Watch = func(frame func() *Grid) {
+		watched = append(watched, frame().ASCII())
+	}
+
+	recorder.Record(func() *Grid { return FromLines([]string{"#."}, nil) })
+	recorder.Record(func() *Grid { return FromLines([]string{".#"}, nil) })
+
+	assert.Equal(t, []string{"#.\n", ".#\n"}, watched)
+	assert.Equal(t, 0, recorder.Frames())
+}
@@ -0,0 +1,215 @@
+// Package trace lets solvers log what they are doing with structured attributes, using log/slog.
+// Each day creates a named Tracer once, and the runner decides which tracers are enabled, at what
+// level and where they write to. A disabled Tracer costs a single comparison, so a hot loop may trace
+// as long as it checks Enabled before building its attributes:
+//
+//	if tracer.Enabled(slog.LevelDebug) {
+//		tracer.Debug("instruction", slog.Int("pointer", c.pointer))
+//	}
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// The level of a disabled Tracer, which is above every level a message can be logged at.
+const OFF = slog.Level(math.MaxInt32)
+
+// Output formats for traced messages.
+const (
+	TEXT = "text"
+	JSON = "json"
+)
+
+/* ---------------------- Tracer Definition and Methods --------------------- */
+
+// Tracer logs the messages of one day. A nil Tracer ignores every message.
+type Tracer struct {
+	name   string
+	level  atomic.Int64
+	logger atomic.Pointer[slog.Logger]
+}
+
+var (
+	mu      sync.Mutex
+	tracers = make(map[string]*Tracer)
+	current = configuration{level: OFF}
+)
+
+// configuration is the handler, level and names of the tracers the runner has enabled.
+type configuration struct {
+	handler slog.Handler
+	level   slog.Level
+	match   func(name string) bool
+}
+
+// New returns the Tracer with the given name, such as "day-17", creating it if necessary.
+// It is normally assigned to a package variable, so it is looked up only once.
+func New(name string) *Tracer {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer, ok := tracers[name]; ok {
+		return tracer
+	}
+	tracer := &Tracer{name: name}
+	tracer.apply(current)
+	tracers[name] = tracer
+	return tracer
+}
+
+// Name returns the name of the Tracer.
+func (t *Tracer) Name() string {
+	if t == nil {
+		return ""
+	}
+	return t.name
+}
+
+// Enabled reports whether messages at the level are logged. It is cheap enough to call from a hot loop.
+func (t *Tracer) Enabled(level slog.Level) bool {
+	return t != nil && int64(level) >= t.level.Load()
+}
+
+// Log logs a message at the level, if it is enabled.
+func (t *Tracer) Log(level slog.Level, message string, attrs ...slog.Attr) {
+	if !t.Enabled(level) {
+		return
+	}
+	t.logger.Load().LogAttrs(context.Background(), level, message, attrs...)
+}
+
+// Debug logs a message at the debug level.
+func (t *Tracer) Debug(message string, attrs ...slog.Attr) {
+	t.Log(slog.LevelDebug, message, attrs...)
+}
+
+// Info logs a message at the info level.
+func (t *Tracer) Info(message string, attrs ...slog.Attr) {
+	t.Log(slog.LevelInfo, message, attrs...)
+}
+
+// Warn logs a message at the warn level.
+func (t *Tracer) Warn(message string, attrs ...slog.Attr) {
+	t.Log(slog.LevelWarn, message, attrs...)
+}
+
+// apply enables or disables the Tracer to match the configuration.
+func (t *Tracer) apply(c configuration) {
+	if c.handler == nil || c.level == OFF || (c.match != nil && !c.match(t.name)) {
+		t.level.Store(int64(OFF))
+		return
+	}
+	t.logger.Store(slog.New(c.handler).With(slog.String("day", t.name)))
+	t.level.Store(int64(c.level))
+}
+
+/* ------------------------------ Configuration ----------------------------- */
+
+// Configure enables the tracers whose names match, logging messages at or above the level to the
+// handler, and disables every other tracer. A nil match enables every tracer, and a nil handler or a
+// level of OFF disables them all. Tracers created later are configured the same way.
+func Configure(handler slog.Handler, level slog.Level, match func(name string) bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = configuration{handler: handler, level: level, match: match}
+	for _, tracer := range tracers {
+		tracer.apply(current)
+	}
+}
+
+// Config is how the runner's tracing options are given, and passed on to isolated child processes.
+type Config struct {
+	// The lowest level traced: "off", "debug", "info", "warn" or "error". Empty is the same as "off".
+	Level string `json:"level,omitempty"`
+	// The days traced, as a comma separated list of day numbers, or "all". Empty is the same as "all".
+	Days string `json:"days,omitempty"`
+	// The file messages are appended to. Empty means stderr.
+	File string `json:"file,omitempty"`
+	// The format of messages, "text" or "json". Empty is the same as "text".
+	Format string `json:"format,omitempty"`
+}
+
+// Validate returns an error if any field of the Config has an invalid value.
+func (c Config) Validate() error {
+	if _, err := ParseLevel(c.Level); err != nil {
+		return err
+	}
+	if _, err := parseDays(c.Days); err != nil {
+		return err
+	}
+	if c.Format != "" && c.Format != TEXT && c.Format != JSON {
+		return fmt.Errorf("invalid log format %q, expected %s or %s", c.Format, TEXT, JSON)
+	}
+	return nil
+}
+
+// Setup configures the tracers as the Config describes. A log file stays open until the process exits.
+func Setup(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	level, _ := ParseLevel(c.Level)
+	if level == OFF {
+		Configure(nil, OFF, nil)
+		return nil
+	}
+
+	var output io.Writer = os.Stderr
+	if c.File != "" {
+		file, err := os.OpenFile(c.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		output = file
+	}
+	days, _ := parseDays(c.Days)
+	Configure(NewHandler(output, c.Format, level), level, days)
+	return nil
+}
+
+// NewHandler returns a handler which writes messages at or above the level to the output, as text or JSON.
+func NewHandler(output io.Writer, format string, level slog.Level) slog.Handler {
+	options := &slog.HandlerOptions{Level: level}
+	if format == JSON {
+		return slog.NewJSONHandler(output, options)
+	}
+	return slog.NewTextHandler(output, options)
+}
+
+// ParseLevel parses the name of a level, such as "debug". "off" and the empty string are OFF.
+func ParseLevel(name string) (slog.Level, error) {
+	if name == "" || strings.EqualFold(name, "off") {
+		return OFF, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return OFF, fmt.Errorf("invalid log level %q, expected off, debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+// parseDays returns a function matching the names of the tracers of the listed days, such as "5,17".
+// Empty and "all" match every tracer, which is returned as nil.
+func parseDays(list string) (func(name string) bool, error) {
+	if list == "" || list == "all" {
+		return nil, nil
+	}
+	names := make(map[string]bool)
+	for _, field := range strings.Split(list, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || day < 1 {
+			return nil, fmt.Errorf("invalid log day %q, expected day numbers such as 5,17 or all", field)
+		}
+		names[fmt.Sprintf("day-%02d", day)] = true
+	}
+	return func(name string) bool { return names[name] }, nil
+}
@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracer(t *testing.T) {
+	defer Configure(nil, OFF, nil)
+	var output bytes.Buffer
+	tracer := New("day-17")
+	other := New("day-05")
+	assert.Same(t, tracer, New("day-17"))
+
+	// Tracers are disabled until configured
+	assert.False(t, tracer.Enabled(slog.LevelError))
+
+	match, err := parseDays("17")
+	assert.NoError(t, err)
+	Configure(NewHandler(&output, JSON, slog.LevelInfo), slog.LevelInfo, match)
+	assert.True(t, tracer.Enabled(slog.LevelInfo))
+	assert.False(t, tracer.Enabled(slog.LevelDebug))
+	assert.False(t, other.Enabled(slog.LevelError))
+
+	tracer.Debug("hidden")
+	tracer.Info("instruction", slog.Int("pointer", 4))
+	other.Warn("hidden")
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 1)
+
+	var message map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &message))
+	assert.Equal(t, "instruction", message["msg"])
+	assert.Equal(t, "day-17", message["day"])
+	assert.Equal(t, 4.0, message["pointer"])
+
+	// Tracers created after configuration are configured too
+	assert.False(t, New("day-18").Enabled(slog.LevelError))
+	Configure(NewHandler(&output, TEXT, slog.LevelDebug), slog.LevelDebug, nil)
+	assert.True(t, New("day-19").Enabled(slog.LevelDebug))
+
+	var nilTracer *Tracer
+	assert.False(t, nilTracer.Enabled(slog.LevelError))
+	nilTracer.Info("ignored")
+}
+
+// A disabled tracer must not allocate, so that it can stay in hot loops.
+func TestDisabledTracerIsFree(t *testing.T) {
+	tracer := New("day-01")
+	Configure(nil, OFF, nil)
+	allocs := testing.AllocsPerRun(1000, func() {
+		if tracer.Enabled(slog.LevelDebug) {
+			tracer.Debug("step", slog.Int("n", 1), slog.String("phase", "loop"))
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Level: "debug", Days: "5, 17", Format: JSON}.Validate())
+	assert.NoError(t, Config{Level: "WARN", Days: "all"}.Validate())
+	assert.Error(t, Config{Level: "verbose"}.Validate())
+	assert.Error(t, Config{Days: "five"}.Validate())
+	assert.Error(t, Config{Days: "0"}.Validate())
+	assert.Error(t, Config{Format: "xml"}.Validate())
+
+	level, err := ParseLevel("off")
+	assert.NoError(t, err)
+	assert.Equal(t, OFF, level)
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	tracer := New("day-01")
+	Configure(nil, OFF, nil)
+	for i := 0; i < b.N; i++ {
+		if tracer.Enabled(slog.LevelDebug) {
+			tracer.Debug("step", slog.Int("n", i))
+		}
+	}
+}
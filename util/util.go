@@ -2,10 +2,13 @@
 package util
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+
+	"shaneholland.dev/aoc-2024/util/parse"
 )
 
 /**
@@ -21,9 +24,10 @@ func ReadFile(filePath string) string {
 
 /**
  * GetLines splits a string into an array of strings by newline characters.
+ * The input is normalized by parse.Normalize, so CRLF line endings are accepted and trailing newlines do not produce empty lines.
  */
 func GetLines(input string) []string {
-	return strings.Split(input, "\n")
+	return strings.Split(parse.Normalize(input), "\n")
 }
 
 /**
@@ -37,7 +41,10 @@ func GetColumns(input string) []string {
 	for i := 0; i < len(lines[0]); i++ {
 		column := ""
 		for _, line := range lines {
-			column += string(line[i])
+			// Lines shorter than the first have nothing in this column
+			if i < len(line) {
+				column += string(line[i])
+			}
 		}
 		columns[i] = column
 	}
@@ -55,6 +62,17 @@ func AtoI(val string) int {
 	return num
 }
 
+/**
+ * Function to return the Integer value of a string, or an error if the string is not an integer.
+ */
+func ParseInt(val string) (int, error) {
+	num, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert %q to an integer", val)
+	}
+	return num, nil
+}
+
 /**
  * Function to find the absolute value of an integer
  */